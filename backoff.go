@@ -0,0 +1,47 @@
+package safezone
+
+import "time"
+
+// Backoff produces a sequence of delays between attempts
+type Backoff interface {
+	// Next returns the next delay and advances the sequence
+	Next() time.Duration
+	// Reset restarts the sequence from the beginning
+	Reset()
+}
+
+var _ Backoff = (*ExpBackoff)(nil)
+
+// ExpBackoff is a Backoff whose delays grow exponentially up to a maximum.
+// It is not safe for concurrent use.
+type ExpBackoff struct {
+	base    time.Duration
+	max     time.Duration
+	factor  float64
+	current time.Duration
+}
+
+// NewExponential creates an ExpBackoff starting at base, multiplying by factor on each step and capped at max
+func NewExponential(base, max time.Duration, factor float64) *ExpBackoff {
+	return &ExpBackoff{base: base, max: max, factor: factor, current: base}
+}
+
+// Next returns the current delay and advances the sequence
+func (b *ExpBackoff) Next() time.Duration {
+	delay := b.current
+	if delay > b.max {
+		delay = b.max
+	}
+	next := float64(b.current) * b.factor
+	if next > float64(b.max) {
+		b.current = b.max
+	} else {
+		b.current = time.Duration(next)
+	}
+	return delay
+}
+
+// Reset restarts the sequence at the base delay
+func (b *ExpBackoff) Reset() {
+	b.current = b.base
+}
@@ -0,0 +1,44 @@
+package safezone
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpBackoff(t *testing.T) {
+	t.Run("Capped", func(t *testing.T) {
+		b := NewExponential(100*time.Millisecond, time.Second, 2)
+		expected := []time.Duration{
+			100 * time.Millisecond,
+			200 * time.Millisecond,
+			400 * time.Millisecond,
+			800 * time.Millisecond,
+			time.Second,
+			time.Second,
+		}
+		for i, want := range expected {
+			if got := b.Next(); got != want {
+				t.Errorf("Step %d: expected %v, got %v", i, want, got)
+			}
+		}
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		b := NewExponential(100*time.Millisecond, time.Second, 2)
+		b.Next()
+		b.Next()
+		b.Reset()
+		if got := b.Next(); got != 100*time.Millisecond {
+			t.Errorf("Reset should restart the sequence, got %v", got)
+		}
+	})
+
+	t.Run("NoOverflow", func(t *testing.T) {
+		b := NewExponential(time.Second, time.Hour, 10)
+		for i := 0; i < 100; i++ {
+			if d := b.Next(); d <= 0 || d > time.Hour {
+				t.Fatalf("Step %d produced an out-of-range delay %v", i, d)
+			}
+		}
+	})
+}
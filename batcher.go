@@ -0,0 +1,86 @@
+package safezone
+
+import (
+	"sync"
+	"time"
+)
+
+// Batcher coalesces individual keyed requests into batched calls
+type Batcher[K comparable, V any] struct {
+	fn      func([]K) (map[K]V, error)
+	window  time.Duration
+	maxSize int
+
+	mu      sync.Mutex
+	pending *batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	seen    map[K]struct{}
+	once    sync.Once
+	done    chan struct{}
+	results map[K]V
+	err     error
+}
+
+// NewBatcher creates a Batcher that calls fn with the keys collected within window,
+// or as soon as maxSize keys are pending. A maxSize of zero or less disables the size limit.
+func NewBatcher[K comparable, V any](fn func([]K) (map[K]V, error), window time.Duration, maxSize int) *Batcher[K, V] {
+	return &Batcher[K, V]{fn: fn, window: window, maxSize: maxSize}
+}
+
+// Get queues key for the next batch and blocks until the batch completes
+func (b *Batcher[K, V]) Get(key K) Result[V] {
+	b.mu.Lock()
+	current := b.pending
+	if current == nil {
+		current = &batch[K, V]{seen: make(map[K]struct{}), done: make(chan struct{})}
+		b.pending = current
+		time.AfterFunc(b.window, func() { b.flush(current) })
+	}
+	if _, ok := current.seen[key]; !ok {
+		current.seen[key] = struct{}{}
+		current.keys = append(current.keys, key)
+	}
+	full := b.maxSize > 0 && len(current.keys) >= b.maxSize
+	b.mu.Unlock()
+
+	if full {
+		b.flush(current)
+	}
+	<-current.done
+
+	if current.err != nil {
+		return Err[V](current.err)
+	}
+	value, ok := current.results[key]
+	if !ok {
+		return Err[V](New("batch returned no result for key").With("key", key))
+	}
+	return Ok(value)
+}
+
+// flush runs the batch function for the given batch exactly once
+func (b *Batcher[K, V]) flush(current *batch[K, V]) {
+	current.once.Do(func() {
+		b.mu.Lock()
+		if b.pending == current {
+			b.pending = nil
+		}
+		b.mu.Unlock()
+
+		defer close(current.done)
+		results, err := b.call(current.keys)
+		if err != nil {
+			current.err = Wrap(err, "batch failed").With("size", len(current.keys))
+		}
+		current.results = results
+	})
+}
+
+// call runs the batch function, converting a panic into an error
+func (b *Batcher[K, V]) call(keys []K) (results map[K]V, err error) {
+	defer Recover(&err)
+	return b.fn(keys)
+}
@@ -0,0 +1,99 @@
+package safezone
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatcher(t *testing.T) {
+	t.Run("CoalescesWithinWindow", func(t *testing.T) {
+		var calls int32
+		b := NewBatcher(func(keys []int) (map[int]int, error) {
+			atomic.AddInt32(&calls, 1)
+			results := make(map[int]int, len(keys))
+			for _, k := range keys {
+				results[k] = k * 10
+			}
+			return results, nil
+		}, 50*time.Millisecond, 0)
+
+		var wg sync.WaitGroup
+		for i := 1; i <= 5; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if v := b.Get(i).Unwrap(); v != i*10 {
+					t.Errorf("Expected %d, got %d", i*10, v)
+				}
+			}(i)
+		}
+		wg.Wait()
+		if calls != 1 {
+			t.Errorf("Expected 1 batch call, got %d", calls)
+		}
+	})
+
+	t.Run("MaxSize", func(t *testing.T) {
+		var calls int32
+		b := NewBatcher(func(keys []int) (map[int]int, error) {
+			atomic.AddInt32(&calls, 1)
+			return map[int]int{keys[0]: 1, keys[1]: 2}, nil
+		}, time.Hour, 2)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				b.Get(i)
+			}(i)
+		}
+		wg.Wait()
+		if calls != 1 {
+			t.Errorf("Expected batch to flush at max size, got %d calls", calls)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		b := NewBatcher(func(keys []int) (map[int]int, error) {
+			return nil, ErrTest
+		}, time.Millisecond, 0)
+		if !errors.Is(b.Get(1).Check(), ErrTest) {
+			t.Error("Get should return the batch function's error")
+		}
+	})
+
+	t.Run("MissingKey", func(t *testing.T) {
+		b := NewBatcher(func(keys []int) (map[int]int, error) {
+			return map[int]int{}, nil
+		}, time.Millisecond, 0)
+		if b.Get(1).Check() == nil {
+			t.Error("Get should return an error when the batch has no result for the key")
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		b := NewBatcher(func(keys []int) (map[int]int, error) {
+			panic("boom")
+		}, 10*time.Millisecond, 0)
+
+		done := make(chan Result[int], 2)
+		for i := 0; i < 2; i++ {
+			go func(i int) { done <- b.Get(i) }(i)
+		}
+		for i := 0; i < 2; i++ {
+			select {
+			case r := <-done:
+				if r.Check() == nil || !strings.Contains(r.Check().Error(), "boom") {
+					t.Errorf("A panicking batch should fail every Get, got %v", r)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Get should not hang when the batch function panics")
+			}
+		}
+	})
+}
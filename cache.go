@@ -0,0 +1,92 @@
+package safezone
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache is a read-through cache of Results with TTL expiry that coalesces concurrent loads per key
+type Cache[K comparable, V any] struct {
+	ttl         time.Duration
+	cacheErrors bool
+	now         func() time.Time
+
+	mu        sync.Mutex
+	entries   map[K]cacheEntry[V]
+	flight    SingleFlight[K, V]
+	lastSweep time.Time
+}
+
+type cacheEntry[V any] struct {
+	result  Result[V]
+	expires time.Time
+}
+
+// NewCache creates a Cache whose entries expire after ttl. When cacheErrors is false,
+// failed loads are returned but not cached, so the next call loads again.
+func NewCache[K comparable, V any](ttl time.Duration, cacheErrors bool) *Cache[K, V] {
+	return &Cache[K, V]{
+		ttl:         ttl,
+		cacheErrors: cacheErrors,
+		now:         time.Now,
+		entries:     make(map[K]cacheEntry[V]),
+	}
+}
+
+// GetOrLoad returns the cached Result for key if it has not expired, otherwise calls loader.
+// Concurrent misses for the same key share a single loader call.
+func (c *Cache[K, V]) GetOrLoad(key K, loader func() (V, error)) Result[V] {
+	if r, ok := c.lookup(key); ok {
+		return r
+	}
+	return c.flight.Do(key, func() (V, error) {
+		// Another load for key may have completed between the lookup and this flight.
+		if r, ok := c.lookup(key); ok {
+			return r.value, r.err
+		}
+		value, err := loader()
+		if err == nil || c.cacheErrors {
+			result := Ok(value)
+			if err != nil {
+				result = Err[V](err)
+			}
+			c.mu.Lock()
+			c.entries[key] = cacheEntry[V]{result: result, expires: c.now().Add(c.ttl)}
+			c.mu.Unlock()
+		}
+		return value, err
+	})
+}
+
+// lookup returns the unexpired entry for key, removing expired entries it comes across.
+// At most once per TTL it also sweeps every expired entry, so keys that are never requested
+// again do not accumulate.
+func (c *Cache[K, V]) lookup(key K) (Result[V], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if now.Sub(c.lastSweep) >= c.ttl {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.lastSweep = now
+	}
+	entry, ok := c.entries[key]
+	if !ok {
+		return Result[V]{}, false
+	}
+	if !now.Before(entry.expires) {
+		delete(c.entries, key)
+		return Result[V]{}, false
+	}
+	return entry.result, true
+}
+
+// Invalidate removes key from the cache
+func (c *Cache[K, V]) Invalidate(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
@@ -0,0 +1,139 @@
+package safezone
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	t.Run("MissThenHit", func(t *testing.T) {
+		c := NewCache[string, int](time.Minute, false)
+		calls := 0
+		loader := func() (int, error) { calls++; return 42, nil }
+		if c.GetOrLoad("key", loader).Unwrap() != 42 {
+			t.Error("GetOrLoad should return the loaded value on a miss")
+		}
+		if c.GetOrLoad("key", loader).Unwrap() != 42 {
+			t.Error("GetOrLoad should return the cached value on a hit")
+		}
+		if calls != 1 {
+			t.Errorf("Expected 1 load, got %d", calls)
+		}
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		current := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		c := NewCache[string, int](time.Minute, false)
+		c.now = func() time.Time { return current }
+		calls := 0
+		loader := func() (int, error) { calls++; return calls, nil }
+		c.GetOrLoad("key", loader)
+		current = current.Add(30 * time.Second)
+		if c.GetOrLoad("key", loader).Unwrap() != 1 {
+			t.Error("Entries should be served within the TTL")
+		}
+		current = current.Add(time.Minute)
+		if c.GetOrLoad("key", loader).Unwrap() != 2 {
+			t.Error("Entries should be reloaded after the TTL")
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		calls := 0
+		loader := func() (int, error) { calls++; return 0, ErrTest }
+
+		skip := NewCache[string, int](time.Minute, false)
+		skip.GetOrLoad("key", loader)
+		skip.GetOrLoad("key", loader)
+		if calls != 2 {
+			t.Errorf("Errors should not be cached by default, got %d loads", calls)
+		}
+
+		calls = 0
+		keep := NewCache[string, int](time.Minute, true)
+		keep.GetOrLoad("key", loader)
+		if !keep.GetOrLoad("key", loader).ContainsErr(ErrTest) || calls != 1 {
+			t.Error("Errors should be cached when cacheErrors is set")
+		}
+	})
+
+	t.Run("ConcurrentDedup", func(t *testing.T) {
+		c := NewCache[string, int](time.Minute, false)
+		var calls int32
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				c.GetOrLoad("key", func() (int, error) {
+					atomic.AddInt32(&calls, 1)
+					time.Sleep(50 * time.Millisecond)
+					return 42, nil
+				})
+			}()
+		}
+		close(start)
+		wg.Wait()
+		if calls != 1 {
+			t.Errorf("Expected concurrent misses to share 1 load, got %d", calls)
+		}
+	})
+
+	t.Run("Sweep", func(t *testing.T) {
+		current := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		c := NewCache[int, int](time.Minute, false)
+		c.now = func() time.Time { return current }
+		for i := 0; i < 100; i++ {
+			c.GetOrLoad(i, func() (int, error) { return i, nil })
+		}
+		current = current.Add(2 * time.Minute)
+		c.GetOrLoad(-1, func() (int, error) { return 0, nil })
+		if len(c.entries) != 1 {
+			t.Errorf("Expired entries should be swept, %d remain", len(c.entries))
+		}
+	})
+
+	t.Run("IgnoresErrorClock", func(t *testing.T) {
+		t.Cleanup(SetClock(func() time.Time { return time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC) }))
+
+		c := NewCache[string, int](time.Minute, false)
+		calls := 0
+		loader := func() (int, error) { calls++; return calls, nil }
+		c.GetOrLoad("key", loader)
+		c.GetOrLoad("key", loader)
+		if calls != 1 {
+			t.Errorf("Stubbing the error clock should not affect cache expiry, got %d loads", calls)
+		}
+	})
+
+	t.Run("PanickingLoader", func(t *testing.T) {
+		c := NewCache[string, int](time.Minute, true)
+		release := make(chan struct{})
+		panics := make(chan interface{}, 2)
+		get := func() {
+			defer func() { panics <- recover() }()
+			r := c.GetOrLoad("key", func() (int, error) {
+				<-release
+				panic("boom")
+			})
+			t.Errorf("GetOrLoad should not return a Result when the loader panics, got %v", r)
+		}
+		go get()
+		time.Sleep(10 * time.Millisecond)
+		go get()
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		for i := 0; i < 2; i++ {
+			if r := <-panics; r != "boom" {
+				t.Errorf("Every concurrent caller should see the panic, got %v", r)
+			}
+		}
+		if r := c.GetOrLoad("key", func() (int, error) { return 7, nil }); r.Unwrap() != 7 {
+			t.Errorf("A panicking load should not be cached, got %v", r)
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package safezone
+
+// Either holds one of two equally valid alternatives: a Left value or a Right value.
+// Unlike Result, neither side implies failure.
+type Either[L, R any] struct {
+	left    L
+	right   R
+	isRight bool
+}
+
+// Left creates an Either holding a Left value
+func Left[L, R any](value L) Either[L, R] {
+	return Either[L, R]{left: value}
+}
+
+// Right creates an Either holding a Right value
+func Right[L, R any](value R) Either[L, R] {
+	return Either[L, R]{right: value, isRight: true}
+}
+
+// IsLeft reports whether the Either holds a Left value
+func (e Either[L, R]) IsLeft() bool {
+	return !e.isRight
+}
+
+// IsRight reports whether the Either holds a Right value
+func (e Either[L, R]) IsRight() bool {
+	return e.isRight
+}
+
+// Fold returns onLeft applied to a Left value, or onRight applied to a Right value.
+// It is a function rather than a method because methods cannot declare type parameters.
+func Fold[L, R, X any](e Either[L, R], onLeft func(L) X, onRight func(R) X) X {
+	if e.isRight {
+		return onRight(e.right)
+	}
+	return onLeft(e.left)
+}
@@ -0,0 +1,35 @@
+package safezone
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestEither(t *testing.T) {
+	describe := func(e Either[int, string]) string {
+		return Fold(e,
+			func(n int) string { return "left:" + strconv.Itoa(n) },
+			func(s string) string { return "right:" + s },
+		)
+	}
+
+	t.Run("Left", func(t *testing.T) {
+		e := Left[int, string](42)
+		if !e.IsLeft() || e.IsRight() {
+			t.Error("Left should construct a Left Either")
+		}
+		if got := describe(e); got != "left:42" {
+			t.Errorf("Fold should dispatch to the Left branch, got %q", got)
+		}
+	})
+
+	t.Run("Right", func(t *testing.T) {
+		e := Right[int]("cached")
+		if !e.IsRight() || e.IsLeft() {
+			t.Error("Right should construct a Right Either")
+		}
+		if got := describe(e); got != "right:cached" {
+			t.Errorf("Fold should dispatch to the Right branch, got %q", got)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package safezone
+
+import (
+	"fmt"
+	"sync"
+)
+
+// GroupResult runs functions concurrently and collects their values by submission index.
+// The zero value is ready to use.
+type GroupResult[T any] struct {
+	group    Group
+	mu       sync.Mutex
+	results  []Result[T]
+	finished []Result[T] // completed before Results was called, awaiting replay
+	stream   chan Result[T]
+	next     int
+	used     map[int]bool
+}
+
+// SetLimit limits the number of active goroutines in the group to at most n
+func (g *GroupResult[T]) SetLimit(n int) {
+	g.group.SetLimit(n)
+}
+
+// Go runs f in a goroutine and returns the index its value will occupy in Wait's results
+func (g *GroupResult[T]) Go(f func() (T, error)) int {
+	g.mu.Lock()
+	index := g.next
+	g.reserve(index)
+	g.mu.Unlock()
+	g.run(index, f)
+	return index
+}
+
+// GoAt runs f in a goroutine and places its value at index in Wait's results.
+// Later calls to Go continue after the highest index used so far.
+// It panics if index is negative or has already been used by Go or GoAt.
+func (g *GroupResult[T]) GoAt(index int, f func() (T, error)) {
+	if index < 0 {
+		panic(fmt.Sprintf("safezone: GoAt with negative index %d", index))
+	}
+	g.mu.Lock()
+	if g.used[index] {
+		g.mu.Unlock()
+		panic(fmt.Sprintf("safezone: GoAt with index %d already in use", index))
+	}
+	g.reserve(index)
+	g.mu.Unlock()
+	g.run(index, f)
+}
+
+func (g *GroupResult[T]) run(index int, f func() (T, error)) {
+	g.group.Go(func() error {
+		value, err := f()
+		r := Ok(value)
+		if err != nil {
+			r = Err[T](err)
+		}
+		g.mu.Lock()
+		g.results[index] = r
+		stream := g.stream
+		if stream == nil {
+			g.finished = append(g.finished, r)
+		}
+		g.mu.Unlock()
+		if stream != nil {
+			stream <- r
+		}
+		return err
+	})
+}
+
+// reserve marks index as used and grows the results to hold it; g.mu must be held
+func (g *GroupResult[T]) reserve(index int) {
+	if g.used == nil {
+		g.used = make(map[int]bool)
+	}
+	g.used[index] = true
+	for len(g.results) <= index {
+		g.results = append(g.results, Result[T]{})
+	}
+	if index >= g.next {
+		g.next = index + 1
+	}
+}
+
+// Wait waits for all goroutines to complete and returns their values by submission index,
+// along with a combined error. Failed tasks and indices that were never submitted hold the zero value.
+func (g *GroupResult[T]) Wait() ([]T, error) {
+	err := g.group.Wait()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	values := make([]T, len(g.results))
+	for i, r := range g.results {
+		values[i] = r.value
+	}
+	return values, err
+}
+
+// WaitResult waits for all goroutines to complete and returns Ok of the values by submission index
+// when every task succeeded, otherwise an Err combining all failures. Values of the tasks that did
+// succeed remain available through Successes.
+func (g *GroupResult[T]) WaitResult() Result[[]T] {
+	values, err := g.Wait()
+	if err != nil {
+		return Err[[]T](err)
+	}
+	return Ok(values)
+}
+
+// Successes returns the values of successful tasks in submission order, skipping failed
+// and never-submitted indices. It should be called after Wait or WaitResult.
+func (g *GroupResult[T]) Successes() []T {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var values []T
+	for _, r := range g.results {
+		if v, ok := r.OkValue(); ok {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// Results returns a channel that receives each task's Result as it finishes, in completion order,
+// and is closed once every task has completed. Results of tasks that finished before the call are
+// delivered too. It should be called after all tasks have been submitted. The channel is unbuffered,
+// so it must be drained until closed; otherwise tasks block and Wait never returns.
+func (g *GroupResult[T]) Results() <-chan Result[T] {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.stream != nil {
+		return g.stream
+	}
+	stream := make(chan Result[T])
+	g.stream = stream
+	replay := g.finished
+	g.finished = nil
+	go func() {
+		for _, r := range replay {
+			stream <- r
+		}
+		g.group.wg.Wait()
+		close(stream)
+	}()
+	return stream
+}
@@ -0,0 +1,134 @@
+package safezone
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGroupResult(t *testing.T) {
+	t.Run("Go", func(t *testing.T) {
+		var g GroupResult[int]
+		for i := 0; i < 5; i++ {
+			i := i
+			if index := g.Go(func() (int, error) {
+				time.Sleep(time.Duration(5-i) * time.Millisecond)
+				return i * 10, nil
+			}); index != i {
+				t.Errorf("Expected index %d, got %d", i, index)
+			}
+		}
+		values, err := g.Wait()
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		for i, v := range values {
+			if v != i*10 {
+				t.Errorf("Expected %d at index %d, got %d", i*10, i, v)
+			}
+		}
+	})
+
+	t.Run("GoAtOutOfOrder", func(t *testing.T) {
+		var g GroupResult[string]
+		g.GoAt(3, func() (string, error) { return "d", nil })
+		g.GoAt(0, func() (string, error) { return "a", nil })
+		g.GoAt(1, func() (string, error) { return "", ErrTest })
+		values, err := g.Wait()
+		if !errors.Is(err, ErrTest) {
+			t.Error("Wait should return the failing task's error")
+		}
+		expected := []string{"a", "", "", "d"}
+		if len(values) != len(expected) {
+			t.Fatalf("Expected %d values, got %d", len(expected), len(values))
+		}
+		for i := range expected {
+			if values[i] != expected[i] {
+				t.Errorf("Expected %q at index %d, got %q", expected[i], i, values[i])
+			}
+		}
+		if g.Go(func() (string, error) { return "e", nil }) != 4 {
+			t.Error("Go should continue after the highest index used")
+		}
+		g.Wait()
+	})
+
+	t.Run("GoAtInvalidIndex", func(t *testing.T) {
+		var g GroupResult[int]
+		g.GoAt(0, func() (int, error) { return 1, nil })
+		for _, index := range []int{-1, 0} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("GoAt should panic on index %d", index)
+					}
+				}()
+				g.GoAt(index, func() (int, error) { return 2, nil })
+			}()
+		}
+		values, _ := g.Wait()
+		if len(values) != 1 || values[0] != 1 {
+			t.Errorf("A rejected GoAt should not run its task, got %v", values)
+		}
+	})
+}
+
+func TestGroupResultWaitResult(t *testing.T) {
+	t.Run("AllSuccess", func(t *testing.T) {
+		var g GroupResult[int]
+		g.Go(func() (int, error) { return 1, nil })
+		g.Go(func() (int, error) { return 2, nil })
+		values := g.WaitResult().Unwrap()
+		if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+			t.Errorf("Expected [1 2], got %v", values)
+		}
+	})
+
+	t.Run("PartialFailure", func(t *testing.T) {
+		var g GroupResult[int]
+		g.Go(func() (int, error) { return 1, nil })
+		g.Go(func() (int, error) { return 0, ErrTest })
+		g.Go(func() (int, error) { return 3, nil })
+		if !g.WaitResult().ContainsErr(ErrTest) {
+			t.Error("WaitResult should return an Err when a task fails")
+		}
+		successes := g.Successes()
+		if len(successes) != 2 || successes[0] != 1 || successes[1] != 3 {
+			t.Errorf("Expected successes [1 3], got %v", successes)
+		}
+	})
+}
+
+func TestGroupResultResults(t *testing.T) {
+	var g GroupResult[int]
+	g.Go(func() (int, error) { return 1, nil })
+	for i := 2; i <= 5; i++ {
+		i := i
+		g.Go(func() (int, error) {
+			time.Sleep(time.Duration(i) * time.Millisecond)
+			if i == 4 {
+				return 0, ErrTest
+			}
+			return i, nil
+		})
+	}
+	time.Sleep(time.Millisecond)
+
+	sum, failures := 0, 0
+	for r := range g.Results() {
+		if v, ok := r.OkValue(); ok {
+			sum += v
+		} else {
+			failures++
+		}
+	}
+	if sum != 1+2+3+5 || failures != 1 {
+		t.Errorf("Expected every Result before the channel closed, got sum=%d failures=%d", sum, failures)
+	}
+	if _, err := g.Wait(); !errors.Is(err, ErrTest) {
+		t.Error("Wait should still report the failure after streaming")
+	}
+	if g.finished != nil {
+		t.Error("Results should stop buffering finished Results once streaming starts")
+	}
+}
@@ -0,0 +1,55 @@
+package safezone
+
+import "sync"
+
+// KeyedMutex provides a separate lock per key, so operations on different keys run
+// concurrently while operations on the same key are serialized. The zero value is ready to use.
+// Entries are reference counted and removed once no goroutine holds or waits for the key.
+type KeyedMutex[K comparable] struct {
+	mu    sync.Mutex
+	locks map[K]*keyedLock
+}
+
+type keyedLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Lock locks key, blocking until it is available
+func (m *KeyedMutex[K]) Lock(key K) {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = make(map[K]*keyedLock)
+	}
+	l, ok := m.locks[key]
+	if !ok {
+		l = &keyedLock{}
+		m.locks[key] = l
+	}
+	l.refs++
+	m.mu.Unlock()
+
+	l.mu.Lock()
+}
+
+// Unlock unlocks key. It panics if key is not locked.
+func (m *KeyedMutex[K]) Unlock(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, ok := m.locks[key]
+	if !ok {
+		panic("safezone: unlock of unlocked key")
+	}
+	l.refs--
+	if l.refs == 0 {
+		delete(m.locks, key)
+	}
+	l.mu.Unlock()
+}
+
+// WithLock runs f while holding the lock for key and returns its error
+func (m *KeyedMutex[K]) WithLock(key K, f func() error) error {
+	m.Lock(key)
+	defer m.Unlock(key)
+	return f()
+}
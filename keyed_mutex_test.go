@@ -0,0 +1,71 @@
+package safezone
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeyedMutex(t *testing.T) {
+	t.Run("SameKeySerializes", func(t *testing.T) {
+		var m KeyedMutex[string]
+		var active, maxActive int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m.WithLock("user-1", func() error {
+					n := atomic.AddInt32(&active, 1)
+					for {
+						max := atomic.LoadInt32(&maxActive)
+						if n <= max || atomic.CompareAndSwapInt32(&maxActive, max, n) {
+							break
+						}
+					}
+					time.Sleep(time.Millisecond)
+					atomic.AddInt32(&active, -1)
+					return nil
+				})
+			}()
+		}
+		wg.Wait()
+		if maxActive != 1 {
+			t.Errorf("Operations on the same key should serialize, saw %d at once", maxActive)
+		}
+	})
+
+	t.Run("DifferentKeysConcurrent", func(t *testing.T) {
+		var m KeyedMutex[int]
+		m.Lock(1)
+		done := make(chan struct{})
+		go func() {
+			m.WithLock(2, func() error { return nil })
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("A held key should not block a different key")
+		}
+		m.Unlock(1)
+	})
+
+	t.Run("ReleasesEntries", func(t *testing.T) {
+		var m KeyedMutex[int]
+		for i := 0; i < 100; i++ {
+			m.WithLock(i, func() error { return nil })
+		}
+		if len(m.locks) != 0 {
+			t.Errorf("Unused keys should be removed, %d remain", len(m.locks))
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		var m KeyedMutex[string]
+		if err := m.WithLock("k", func() error { return ErrTest }); err != ErrTest {
+			t.Errorf("WithLock should return f's error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package safezone
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiError holds several errors while keeping each one inspectable.
+// errors.Is and errors.As match against every held error.
+type MultiError struct {
+	errs []error
+}
+
+// NewMultiError returns a MultiError holding the non-nil errors, or nil if there are none
+func NewMultiError(errs ...error) *MultiError {
+	var kept []error
+	for _, err := range errs {
+		if err != nil {
+			kept = append(kept, err)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return &MultiError{errs: kept}
+}
+
+// Error lists each error's message on its own line, without the context or stack trace of any *Error
+func (m *MultiError) Error() string {
+	var b strings.Builder
+	if len(m.errs) == 1 {
+		b.WriteString("1 error occurred:")
+	} else {
+		fmt.Fprintf(&b, "%d errors occurred:", len(m.errs))
+	}
+	for _, err := range m.errs {
+		b.WriteString("\n\t* ")
+		b.WriteString(plainMessage(err))
+	}
+	return b.String()
+}
+
+// Errors returns a copy of the held errors
+func (m *MultiError) Errors() []error {
+	return append([]error(nil), m.errs...)
+}
+
+// Unwrap returns the held errors so errors.Is and errors.As can inspect each of them
+func (m *MultiError) Unwrap() []error {
+	return m.errs
+}
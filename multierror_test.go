@@ -0,0 +1,90 @@
+package safezone
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMultiError(t *testing.T) {
+	errA, errB := errors.New("a failed"), errors.New("b failed")
+
+	t.Run("Group", func(t *testing.T) {
+		var g Group
+		g.Go(func() error { return errA })
+		g.Go(func() error { return Wrap(ErrTest, "task failed") })
+		g.Go(func() error { return nil })
+		g.Go(func() error { return errB })
+		err := g.Wait()
+
+		var multi *MultiError
+		if !errors.As(err, &multi) {
+			t.Fatalf("Wait should return a *MultiError, got %T", err)
+		}
+		if len(multi.Errors()) != 3 {
+			t.Errorf("Errors should return every failure, got %v", multi.Errors())
+		}
+		if !errors.Is(err, ErrTest) || !errors.Is(err, errA) || !errors.Is(err, errB) {
+			t.Error("errors.Is should find each sentinel among the held errors")
+		}
+		var ze *Error
+		if !errors.As(err, &ze) {
+			t.Error("errors.As should find an *Error among the held errors")
+		}
+	})
+
+	t.Run("Aggregators", func(t *testing.T) {
+		ctx := context.Background()
+		fail := func() error { return ErrTest }
+
+		_, all := All(ctx, func(context.Context) (int, error) { return 0, ErrTest })
+		gather := Gather(ctx, 0, func(a, n int) int { return a + n }, func() (int, error) { return 0, ErrTest }).Check()
+		ch := make(chan error, 1)
+		ch <- ErrTest
+		close(ch)
+		fanIn := FanIn(ctx, ch)
+		p := NewPool(1)
+		p.Submit(fail)
+		pool := p.Close()
+		var g Group
+		block := make(chan struct{})
+		defer close(block)
+		g.Go(func() error { <-block; return nil })
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		waitCtx := g.WaitContext(cancelled)
+
+		for name, err := range map[string]error{"All": all, "Gather": gather, "FanIn": fanIn, "Pool.Close": pool, "WaitContext": waitCtx} {
+			var multi *MultiError
+			if !errors.As(err, &multi) {
+				t.Errorf("%s should return a *MultiError, got %T", name, err)
+			}
+		}
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		err := NewMultiError(errA, nil, New("b failed"))
+		want := "2 errors occurred:\n\t* a failed\n\t* b failed"
+		if err.Error() != want {
+			t.Errorf("Unexpected format:\n%s", err.Error())
+		}
+		if strings.Contains(err.Error(), "Stack Trace") {
+			t.Error("MultiError should not include stack traces")
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if NewMultiError(nil, nil) != nil {
+			t.Error("NewMultiError should return nil without errors")
+		}
+	})
+
+	t.Run("ErrorsCopy", func(t *testing.T) {
+		err := NewMultiError(errA, errB)
+		err.Errors()[0] = nil
+		if err.Errors()[0] != errA {
+			t.Error("Errors should return a copy")
+		}
+	})
+}
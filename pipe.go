@@ -0,0 +1,56 @@
+package safezone
+
+import (
+	"context"
+	"sync"
+)
+
+// Pipe transforms the Ok values read from in with f, using at most concurrency workers,
+// and emits the outcomes on the returned channel. Err Results pass through unchanged.
+// Output order is not preserved. The output channel is unbuffered, so a slow consumer
+// applies backpressure to the workers. It is closed once in is closed and drained, or when ctx is done.
+func Pipe[T, U any](ctx context.Context, in <-chan Result[T], concurrency int, f func(T) (U, error)) <-chan Result[U] {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	out := make(chan Result[U])
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				var r Result[T]
+				var ok bool
+				select {
+				case <-ctx.Done():
+					return
+				case r, ok = <-in:
+					if !ok {
+						return
+					}
+				}
+
+				var res Result[U]
+				if r.err != nil {
+					res = Err[U](r.err)
+				} else if value, err := f(r.value); err != nil {
+					res = Err[U](err)
+				} else {
+					res = Ok(value)
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case out <- res:
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
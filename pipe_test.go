@@ -0,0 +1,84 @@
+package safezone
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPipe(t *testing.T) {
+	t.Run("Complete", func(t *testing.T) {
+		in := make(chan Result[int])
+		go func() {
+			defer close(in)
+			for i := 1; i <= 10; i++ {
+				in <- Ok(i)
+			}
+		}()
+		var got []int
+		for r := range Pipe(context.Background(), in, 3, func(n int) (int, error) { return n * n, nil }) {
+			got = append(got, r.Unwrap())
+		}
+		sort.Ints(got)
+		if len(got) != 10 {
+			t.Fatalf("Expected 10 results, got %d", len(got))
+		}
+		for i, v := range got {
+			if v != (i+1)*(i+1) {
+				t.Errorf("Missing square of %d, got %v", i+1, got)
+				break
+			}
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		errOdd := errors.New("odd")
+		in := make(chan Result[int], 3)
+		in <- Ok(1)
+		in <- Err[int](ErrTest)
+		in <- Ok(2)
+		close(in)
+		var calls int32
+		var oks, upstream, transformed int
+		for r := range Pipe(context.Background(), in, 2, func(n int) (string, error) {
+			atomic.AddInt32(&calls, 1)
+			if n%2 == 1 {
+				return "", errOdd
+			}
+			return "even", nil
+		}) {
+			switch {
+			case r.ContainsErr(ErrTest):
+				upstream++
+			case r.ContainsErr(errOdd):
+				transformed++
+			default:
+				oks++
+			}
+		}
+		if oks != 1 || upstream != 1 || transformed != 1 {
+			t.Errorf("Unexpected outcomes: %d ok, %d upstream, %d transformed", oks, upstream, transformed)
+		}
+		if calls != 2 {
+			t.Errorf("f should only be called for Ok values, got %d calls", calls)
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan Result[int])
+		out := Pipe(ctx, in, 2, func(n int) (int, error) { return n, nil })
+		cancel()
+		select {
+		case _, ok := <-out:
+			if ok {
+				t.Error("Pipe should not emit after cancellation")
+			}
+		case <-time.After(time.Second):
+			t.Error("Pipe should close its output when ctx is cancelled")
+		}
+	})
+}
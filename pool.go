@@ -0,0 +1,85 @@
+package safezone
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrPoolClosed is returned when submitting work to a closed Pool
+var ErrPoolClosed = errors.New("pool is closed")
+
+// Pool runs submitted functions on a fixed number of reused goroutines and collects their errors
+type Pool struct {
+	tasks   chan func() error
+	done    chan struct{}
+	wg      sync.WaitGroup
+	pending sync.WaitGroup
+	mu      sync.Mutex
+	closed  bool
+	errMux  sync.Mutex
+	errs    []error
+}
+
+// NewPool starts a Pool with the given number of workers
+func NewPool(workers int) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+	p := &Pool{tasks: make(chan func() error, workers), done: make(chan struct{})}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+	for f := range p.tasks {
+		if err := f(); err != nil {
+			p.errMux.Lock()
+			p.errs = append(p.errs, err)
+			p.errMux.Unlock()
+		}
+	}
+}
+
+// Submit queues f to run on the pool, blocking while the queue is full.
+// It returns ErrPoolClosed if the pool has been closed, including while Submit is blocked.
+func (p *Pool) Submit(f func() error) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrPoolClosed
+	}
+	p.pending.Add(1)
+	p.mu.Unlock()
+	defer p.pending.Done()
+
+	select {
+	case p.tasks <- f:
+		return nil
+	case <-p.done:
+		return ErrPoolClosed
+	}
+}
+
+// Close stops accepting work, waits for queued work to finish and returns a *MultiError of every task error.
+// Submit calls blocked on a full queue return ErrPoolClosed.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.done)
+		p.mu.Unlock()
+		p.pending.Wait()
+		close(p.tasks)
+	} else {
+		p.mu.Unlock()
+	}
+	p.wg.Wait()
+	if len(p.errs) == 0 {
+		return nil
+	}
+	return NewMultiError(p.errs...)
+}
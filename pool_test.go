@@ -0,0 +1,80 @@
+package safezone
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	t.Run("RunsAll", func(t *testing.T) {
+		p := NewPool(3)
+		var count int32
+		for i := 0; i < 100; i++ {
+			if err := p.Submit(func() error {
+				atomic.AddInt32(&count, 1)
+				return nil
+			}); err != nil {
+				t.Fatalf("Unexpected submit error: %v", err)
+			}
+		}
+		if err := p.Close(); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if count != 100 {
+			t.Errorf("Expected 100 tasks to run, got %d", count)
+		}
+	})
+
+	t.Run("AggregatesErrors", func(t *testing.T) {
+		p := NewPool(2)
+		for i := 0; i < 10; i++ {
+			i := i
+			p.Submit(func() error {
+				if i%5 == 0 {
+					return ErrTest
+				}
+				return nil
+			})
+		}
+		err := p.Close()
+		if !errors.Is(err, ErrTest) {
+			t.Error("Close should return the collected errors")
+		}
+	})
+
+	t.Run("SubmitAfterClose", func(t *testing.T) {
+		p := NewPool(1)
+		p.Close()
+		if err := p.Submit(func() error { return nil }); !errors.Is(err, ErrPoolClosed) {
+			t.Error("Submit after Close should return ErrPoolClosed")
+		}
+	})
+
+	t.Run("SubmitDuringClose", func(t *testing.T) {
+		p := NewPool(1)
+		release := make(chan struct{})
+		var inner error
+		p.Submit(func() error {
+			<-release
+			inner = p.Submit(func() error { return nil })
+			return nil
+		})
+		p.Submit(func() error { return nil })
+
+		close(release)
+		time.Sleep(20 * time.Millisecond)
+		closed := make(chan error, 1)
+		go func() { closed <- p.Close() }()
+
+		select {
+		case <-closed:
+		case <-time.After(time.Second):
+			t.Fatal("Close should not deadlock when a task submits while the queue is full")
+		}
+		if !errors.Is(inner, ErrPoolClosed) {
+			t.Errorf("A Submit blocked during Close should return ErrPoolClosed, got %v", inner)
+		}
+	})
+}
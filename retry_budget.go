@@ -0,0 +1,59 @@
+package safezone
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RetryBudget is a token bucket that caps the total number of retries shared across calls,
+// so that retries cannot amplify an outage. It is safe for concurrent use.
+type RetryBudget struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewRetryBudget creates a RetryBudget that refills at ratePerSec tokens per second and holds at most burst tokens.
+// It starts full.
+func NewRetryBudget(ratePerSec float64, burst int) *RetryBudget {
+	return &RetryBudget{
+		rate:   ratePerSec,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// Allow reports whether a retry may proceed, consuming a token if so
+func (b *RetryBudget) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed*b.rate)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// RetryWithBudget retries a function with exponential backoff like Retry, but each retry
+// must first take a token from budget. When the budget is empty it fails fast with the last error.
+// The first attempt never consumes a token. A nil budget allows unlimited retries.
+func RetryWithBudget(ctx context.Context, f func() error, maxRetries int, budget *RetryBudget) error {
+	_, err := retryCtx(ctx, func(context.Context) error { return f() }, maxRetries, func(i int, err error) error {
+		if budget == nil || i == maxRetries-1 || budget.Allow() {
+			return nil
+		}
+		return Wrap(err, "retry budget exhausted")
+	})
+	return err
+}
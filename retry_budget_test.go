@@ -0,0 +1,91 @@
+package safezone
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryBudget(t *testing.T) {
+	defer func(old time.Duration) { RetryMaxDelay = old }(RetryMaxDelay)
+	RetryMaxDelay = time.Millisecond
+
+	t.Run("Exhausted", func(t *testing.T) {
+		budget := NewRetryBudget(0, 2)
+		var attempts int32
+		failing := func() error {
+			atomic.AddInt32(&attempts, 1)
+			return ErrTest
+		}
+
+		err := RetryWithBudget(context.Background(), failing, 5, budget)
+		if !errors.Is(err, ErrTest) || !strings.Contains(err.Error(), "retry budget exhausted") {
+			t.Errorf("Expected a budget exhaustion error, got %v", err)
+		}
+		if attempts != 3 {
+			t.Errorf("Expected 1 attempt plus 2 budgeted retries, got %d", attempts)
+		}
+
+		atomic.StoreInt32(&attempts, 0)
+		RetryWithBudget(context.Background(), failing, 5, budget)
+		if attempts != 1 {
+			t.Errorf("Retries should be suppressed once the budget is exhausted, got %d attempts", attempts)
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		budget := NewRetryBudget(0, 10)
+		var attempts int32
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				RetryWithBudget(context.Background(), func() error {
+					atomic.AddInt32(&attempts, 1)
+					return ErrTest
+				}, 5, budget)
+			}()
+		}
+		wg.Wait()
+		if attempts != 30 {
+			t.Errorf("Expected 20 first attempts plus 10 shared retries, got %d", attempts)
+		}
+	})
+
+	t.Run("Refill", func(t *testing.T) {
+		current := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		budget := NewRetryBudget(2, 1)
+		budget.now = func() time.Time { return current }
+		budget.last = current
+		if !budget.Allow() || budget.Allow() {
+			t.Fatal("Budget should allow exactly its burst")
+		}
+		current = current.Add(500 * time.Millisecond)
+		if !budget.Allow() {
+			t.Error("Budget should refill at its rate")
+		}
+	})
+
+	t.Run("NilBudget", func(t *testing.T) {
+		attempts := 0
+		err := RetryWithBudget(context.Background(), func() error {
+			attempts++
+			return ErrTest
+		}, 3, nil)
+		if !errors.Is(err, ErrTest) || attempts != 3 {
+			t.Errorf("A nil budget should allow every retry, got %d attempts and %v", attempts, err)
+		}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		budget := NewRetryBudget(0, 0)
+		if err := RetryWithBudget(context.Background(), func() error { return nil }, 3, budget); err != nil {
+			t.Errorf("The first attempt should not need the budget, got %v", err)
+		}
+	})
+}
@@ -2,33 +2,157 @@ package safezone
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"math/rand"
+	"reflect"
 	"runtime"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // Error represents an error with additional context and stack trace
 type Error struct {
+	mu         sync.RWMutex
 	err        error
+	cause      error
 	context    map[string]interface{}
+	keys       []string
 	stackTrace string
+	createdAt  time.Time
+	severity   Severity
+	status     int
+	code       string
+	hint       string
+	traceID    string
+	spanID     string
+	tags       map[string]struct{}
+	secrets    map[string]struct{}
 }
 
 func (e *Error) Error() string {
-	return fmt.Sprintf("%v\nContext: %v\nStack Trace:\n%s", e.err, e.context, e.stackTrace)
+	return fmt.Sprintf("%v\nContext: %v\nStack Trace:\n%s", e.err, e.Context(), e.stackTrace)
 }
 
-func (e *Error) Unwrap() error { return e.err }
+// MarshalJSON encodes the error as a JSON object, redacting sensitive context values
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Message    string                 `json:"message"`
+		Code       string                 `json:"code,omitempty"`
+		Hint       string                 `json:"hint,omitempty"`
+		TraceID    string                 `json:"trace_id,omitempty"`
+		SpanID     string                 `json:"span_id,omitempty"`
+		Context    map[string]interface{} `json:"context,omitempty"`
+		Severity   string                 `json:"severity"`
+		Status     int                    `json:"status"`
+		Time       time.Time              `json:"time"`
+		StackTrace string                 `json:"stack_trace"`
+	}{
+		Message:    e.err.Error(),
+		Code:       e.Code(),
+		Hint:       e.Hint(),
+		TraceID:    e.TraceID(),
+		SpanID:     e.SpanID(),
+		Context:    e.Context(),
+		Severity:   e.Severity().String(),
+		Status:     e.Status(),
+		Time:       e.createdAt,
+		StackTrace: e.stackTrace,
+	})
+}
+
+// UnmarshalJSON reconstructs an error from the output of MarshalJSON. The message becomes a plain
+// error and the stack trace is kept as text. Redacted context values stay redacted.
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Message    string                 `json:"message"`
+		Code       string                 `json:"code"`
+		Hint       string                 `json:"hint"`
+		TraceID    string                 `json:"trace_id"`
+		SpanID     string                 `json:"span_id"`
+		Context    map[string]interface{} `json:"context"`
+		Severity   string                 `json:"severity"`
+		Status     int                    `json:"status"`
+		Time       time.Time              `json:"time"`
+		StackTrace string                 `json:"stack_trace"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	severity, err := parseSeverity(v.Severity)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = errors.New(v.Message)
+	e.cause = nil
+	e.code = v.Code
+	e.hint = v.Hint
+	e.traceID = v.TraceID
+	e.spanID = v.SpanID
+	e.severity = severity
+	e.status = v.Status
+	e.createdAt = v.Time
+	e.stackTrace = v.StackTrace
+	e.context = make(map[string]interface{}, len(v.Context))
+	e.keys = nil
+	e.secrets = nil
+	keys := make([]string, 0, len(v.Context))
+	for key := range v.Context {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		e.setContext(key, v.Context[key])
+	}
+	return nil
+}
+
+// LogValue implements slog.LogValuer, logging the error's structured fields with sensitive context redacted
+func (e *Error) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.String("message", e.err.Error())}
+	for _, f := range []struct{ key, value string }{
+		{"code", e.Code()},
+		{"hint", e.Hint()},
+		{"trace_id", e.TraceID()},
+		{"span_id", e.SpanID()},
+	} {
+		if f.value != "" {
+			attrs = append(attrs, slog.String(f.key, f.value))
+		}
+	}
+	if ctx := e.Context(); len(ctx) > 0 {
+		attrs = append(attrs, slog.Any("context", ctx))
+	}
+	attrs = append(attrs,
+		slog.String("severity", e.Severity().String()),
+		slog.Int("status", e.Status()),
+	)
+	return slog.GroupValue(attrs...)
+}
+
+func (e *Error) Unwrap() error {
+	if e.cause != nil {
+		return e.cause
+	}
+	return e.err
+}
 
 // New creates a new Error with stack trace
 func New(message string) *Error {
-	return &Error{
-		err:        errors.New(message),
-		context:    make(map[string]interface{}),
-		stackTrace: getStackTrace(),
-	}
+	return created(newError(errors.New(message), 1))
+}
+
+// Newf creates a new Error with a formatted message and stack trace
+func Newf(format string, args ...interface{}) *Error {
+	return created(newError(fmt.Errorf(format, args...), 1))
 }
 
 // Wrap wraps an existing error with additional context
@@ -36,28 +160,680 @@ func Wrap(err error, message string) *Error {
 	if err == nil {
 		return nil
 	}
+	return created(newError(fmt.Errorf("%s: %w", message, err), 1))
+}
+
+// WithStack attaches a stack trace to an existing error without changing its message
+func WithStack(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	return created(newError(err, 1))
+}
+
+// Wrapf wraps an existing error with a formatted message.
+// err is wrapped automatically, so format should not contain the %w verb.
+func Wrapf(err error, format string, args ...interface{}) *Error {
+	if err == nil {
+		return nil
+	}
+	return created(newError(fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err), 1))
+}
+
+// WrapIf wraps a non-nil error with a message and context fields, returning nil for a nil error
+func WrapIf(err error, message string, fields map[string]interface{}) error {
+	if err == nil {
+		return nil
+	}
+	e := newError(fmt.Errorf("%s: %w", message, err), 1)
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		e.setContext(key, fields[key])
+	}
+	return created(e)
+}
+
+// Guard returns an Error with the given message when cond is false, otherwise nil
+func Guard(cond bool, msg string) error {
+	if cond {
+		return nil
+	}
+	return created(newError(errors.New(msg), 1))
+}
+
+// Guardf is like Guard but formats the message according to a format specifier
+func Guardf(cond bool, format string, args ...interface{}) error {
+	if cond {
+		return nil
+	}
+	return created(newError(fmt.Errorf(format, args...), 1))
+}
+
+// Validate runs every check and returns nil if all pass, otherwise an Error wrapping a *MultiError
+// of every failure, with the number of failures in its context under "failures"
+func Validate(checks ...func() error) error {
+	var errs []error
+	for _, check := range checks {
+		if err := check(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return created(newError(fmt.Errorf("validation failed: %w", NewMultiError(errs...)), 1).With("failures", len(errs)))
+}
+
+// clock timestamps new errors; it is read concurrently by every constructor
+var clock atomic.Pointer[func() time.Time]
+
+// Now returns the current time according to the clock used to timestamp new errors
+func Now() time.Time {
+	if now := clock.Load(); now != nil {
+		return (*now)()
+	}
+	return time.Now()
+}
+
+// SetClock replaces the clock used to timestamp new errors, so tests can pin it; nil restores time.Now.
+// It is safe to call while errors are being created. The returned func restores the previous clock.
+func SetClock(now func() time.Time) (restore func()) {
+	var next *func() time.Time
+	if now != nil {
+		next = &now
+	}
+	prev := clock.Swap(next)
+	return func() { clock.Store(prev) }
+}
+
+var (
+	errorHookMux sync.RWMutex
+	errorHook    func(*Error)
+)
+
+// SetErrorHook registers a hook that is called synchronously with every Error once its constructor
+// has finished, so context added by constructors such as WrapIf is visible; context added later
+// with With is not. Passing nil removes the hook. Errors created while the hook runs on the same
+// goroutine do not trigger it again.
+func SetErrorHook(hook func(*Error)) {
+	errorHookMux.Lock()
+	defer errorHookMux.Unlock()
+	errorHook = hook
+}
+
+// created passes a fully constructed Error to the error hook, if any, and returns it.
+// An Error whose stack trace passes through runHook was created by the hook itself and is skipped.
+func created(e *Error) *Error {
+	errorHookMux.RLock()
+	hook := errorHook
+	errorHookMux.RUnlock()
+	if hook == nil || strings.Contains(e.stackTrace, hookFrame) {
+		return e
+	}
+	runHook(hook, e)
+	return e
+}
+
+// hookFrame is how runHook appears in a captured stack trace
+var hookFrame = runtime.FuncForPC(reflect.ValueOf(runHook).Pointer()).Name() + "\n"
+
+// runHook calls hook with e; it is kept out of line so that it shows up in stack traces
+//
+//go:noinline
+func runHook(hook func(*Error), e *Error) {
+	hook(e)
+}
+
+// newError creates an Error whose stack trace starts skip frames above its caller
+func newError(err error, skip int) *Error {
 	return &Error{
-		err:        fmt.Errorf("%s: %w", message, err),
+		err:        err,
 		context:    make(map[string]interface{}),
-		stackTrace: getStackTrace(),
+		stackTrace: getStackTrace(skip + 1),
+		createdAt:  Now(),
+	}
+}
+
+// pkgPrefix is the prefix of every function name in this package, e.g. "example.com/safezone."
+var pkgPrefix = func() string {
+	name := runtime.FuncForPC(reflect.ValueOf(New).Pointer()).Name()
+	return name[:strings.LastIndex(name, ".")+1]
+}()
+
+// Location returns the file:line of the first frame in the stack trace outside this package,
+// or an empty string if there is none. Frames from this package's tests count as outside.
+func (e *Error) Location() string {
+	lines := strings.Split(e.stackTrace, "\n")
+	for i := 0; i+1 < len(lines); i += 2 {
+		fn, loc := lines[i], strings.TrimPrefix(lines[i+1], "\t")
+		file := loc[:strings.LastIndex(loc, ":")+1]
+		if strings.HasPrefix(fn, pkgPrefix) && !strings.HasSuffix(file, "_test.go:") {
+			continue
+		}
+		return loc
 	}
+	return ""
+}
+
+// Time returns when the error was created
+func (e *Error) Time() time.Time {
+	return e.createdAt
 }
 
-// With adds context to the error
+// MaxContextSize limits how many context keys an Error retains. When a new key would exceed it,
+// the oldest key is dropped; overwriting an existing key keeps its position.
+// Zero or a negative value means unlimited, which is the default.
+var MaxContextSize = 0
+
+// With adds context to the error. It is safe to call concurrently on a shared Error.
 func (e *Error) With(key string, value interface{}) *Error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.setContext(key, value)
+	return e
+}
+
+// setContext stores a context value, enforcing MaxContextSize. The caller must hold e.mu.
+func (e *Error) setContext(key string, value interface{}) {
+	if _, ok := e.context[key]; !ok {
+		e.keys = append(e.keys, key)
+	}
 	e.context[key] = value
+	for MaxContextSize > 0 && len(e.keys) > MaxContextSize {
+		oldest := e.keys[0]
+		e.keys = e.keys[1:]
+		delete(e.context, oldest)
+		delete(e.secrets, oldest)
+	}
+}
+
+// WithCause sets the underlying cause returned by Unwrap, keeping the message, context and stack trace.
+// The error the message already wraps stays reachable through errors.Is and errors.As.
+func (e *Error) WithCause(cause error) *Error {
+	e.cause = cause
 	return e
 }
 
-func getStackTrace() string {
-	buf := make([]byte, 1024)
+// Clone returns a copy of the error whose context can be changed without affecting the original
+func (e *Error) Clone() *Error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	clone := &Error{
+		err:        e.err,
+		cause:      e.cause,
+		context:    make(map[string]interface{}, len(e.context)),
+		keys:       append([]string(nil), e.keys...),
+		stackTrace: e.stackTrace,
+		createdAt:  e.createdAt,
+		severity:   e.severity,
+		status:     e.status,
+		code:       e.code,
+		hint:       e.hint,
+		traceID:    e.traceID,
+		spanID:     e.spanID,
+	}
+	for key, value := range e.context {
+		clone.context[key] = value
+	}
+	if e.tags != nil {
+		clone.tags = make(map[string]struct{}, len(e.tags))
+		for tag := range e.tags {
+			clone.tags[tag] = struct{}{}
+		}
+	}
+	if e.secrets != nil {
+		clone.secrets = make(map[string]struct{}, len(e.secrets))
+		for key := range e.secrets {
+			clone.secrets[key] = struct{}{}
+		}
+	}
+	return clone
+}
+
+// Redacted is the placeholder shown in place of sensitive context values
+const Redacted = "[REDACTED]"
+
+var (
+	redactMux    sync.RWMutex
+	redactedKeys = make(map[string]struct{})
+)
+
+// RedactKeys marks context keys whose values are redacted on every Error.
+// It returns a function that unmarks the keys this call added, leaving keys that were
+// already registered in place.
+func RedactKeys(keys ...string) (restore func()) {
+	redactMux.Lock()
+	defer redactMux.Unlock()
+	var added []string
+	for _, key := range keys {
+		if _, ok := redactedKeys[key]; !ok {
+			redactedKeys[key] = struct{}{}
+			added = append(added, key)
+		}
+	}
+	return func() {
+		redactMux.Lock()
+		defer redactMux.Unlock()
+		for _, key := range added {
+			delete(redactedKeys, key)
+		}
+	}
+}
+
+// WithSecret adds sensitive context to the error that is redacted when formatted
+func (e *Error) WithSecret(key string, value interface{}) *Error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.secrets == nil {
+		e.secrets = make(map[string]struct{})
+	}
+	e.secrets[key] = struct{}{}
+	e.setContext(key, value)
+	return e
+}
+
+// Context returns a copy of the error's context with sensitive values redacted
+func (e *Error) Context() map[string]interface{} {
+	redactMux.RLock()
+	defer redactMux.RUnlock()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	ctx := make(map[string]interface{}, len(e.context))
+	for key, value := range e.context {
+		_, secret := e.secrets[key]
+		_, global := redactedKeys[key]
+		if secret || global {
+			value = Redacted
+		}
+		ctx[key] = value
+	}
+	return ctx
+}
+
+// UnsafeContext returns a copy of the error's context including sensitive values
+func (e *Error) UnsafeContext() map[string]interface{} {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	ctx := make(map[string]interface{}, len(e.context))
+	for key, value := range e.context {
+		ctx[key] = value
+	}
+	return ctx
+}
+
+// Severity classifies how serious an error is
+type Severity int
+
+// Severity levels, from least to most serious
+const (
+	SeverityDebug Severity = iota + 1
+	SeverityInfo
+	SeverityWarn
+	SeverityError
+	SeverityFatal
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityDebug:
+		return "debug"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarn:
+		return "warn"
+	case SeverityError:
+		return "error"
+	case SeverityFatal:
+		return "fatal"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// parseSeverity is the inverse of Severity.String; an empty string yields the zero Severity
+func parseSeverity(name string) (Severity, error) {
+	if name == "" {
+		return 0, nil
+	}
+	for s := SeverityDebug; s <= SeverityFatal; s++ {
+		if s.String() == name {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", name)
+}
+
+// WithSeverity sets the severity of the error
+func (e *Error) WithSeverity(s Severity) *Error {
+	e.severity = s
+	return e
+}
+
+// Severity returns the highest severity found in the error chain, defaulting to SeverityError
+func (e *Error) Severity() Severity {
+	var highest Severity
+	walkErrors(e, func(ze *Error) bool {
+		if ze.severity > highest {
+			highest = ze.severity
+		}
+		return true
+	})
+	if highest == 0 {
+		return SeverityError
+	}
+	return highest
+}
+
+// IsFatal reports whether the error's severity is SeverityFatal
+func (e *Error) IsFatal() bool {
+	return e.Severity() == SeverityFatal
+}
+
+// PanicIfFatal panics with err if it contains an *Error of fatal severity, and does nothing otherwise
+func PanicIfFatal(err error) {
+	if ze, ok := AsError(err); ok && ze.IsFatal() {
+		panic(err)
+	}
+}
+
+// WithStatus sets the HTTP status code associated with the error
+func (e *Error) WithStatus(code int) *Error {
+	e.status = code
+	return e
+}
+
+// Status returns the nearest HTTP status code in the error chain, defaulting to 500
+func (e *Error) Status() int {
+	return StatusOf(e)
+}
+
+// StatusOf returns the nearest HTTP status code in err's chain, defaulting to 500
+func StatusOf(err error) int {
+	status := 500
+	walkErrors(err, func(ze *Error) bool {
+		if ze.status != 0 {
+			status = ze.status
+			return false
+		}
+		return true
+	})
+	return status
+}
+
+// WithCode sets a machine-readable code on the error
+func (e *Error) WithCode(code string) *Error {
+	e.code = code
+	return e
+}
+
+// Code returns the nearest code in the error chain, or an empty string if there is none
+func (e *Error) Code() string {
+	return codeOf(e)
+}
+
+// Is reports whether the error matches target. A target *Error with a code matches any
+// Error carrying the same code; otherwise the errors must share the same underlying error.
+// When a cause has been set with WithCause, the error the message wraps is matched too.
+func (e *Error) Is(target error) bool {
+	if t, ok := target.(*Error); ok {
+		if t.code != "" && e.code == t.code {
+			return true
+		}
+		if t.code == "" && e.err == t.err {
+			return true
+		}
+	}
+	return e.cause != nil && errors.Is(e.err, target)
+}
+
+// As finds the first error matching target in the error the message wraps, when a cause
+// has been set with WithCause and so Unwrap no longer leads there
+func (e *Error) As(target interface{}) bool {
+	return e.cause != nil && errors.As(e.err, target)
+}
+
+// WithHint sets a user-facing remediation message. It is not included in Error().
+func (e *Error) WithHint(hint string) *Error {
+	e.hint = hint
+	return e
+}
+
+// Hint returns the nearest hint in the error chain, or an empty string if there is none
+func (e *Error) Hint() string {
+	var hint string
+	walkErrors(e, func(ze *Error) bool {
+		hint = ze.hint
+		return hint == ""
+	})
+	return hint
+}
+
+// WithTrace stamps the error with a trace and span ID for correlation with a tracing backend
+func (e *Error) WithTrace(traceID, spanID string) *Error {
+	e.traceID = traceID
+	e.spanID = spanID
+	return e
+}
+
+// TraceID returns the nearest trace ID in the error chain, or an empty string if there is none
+func (e *Error) TraceID() string {
+	var id string
+	walkErrors(e, func(ze *Error) bool {
+		id = ze.traceID
+		return id == ""
+	})
+	return id
+}
+
+// SpanID returns the nearest span ID in the error chain, or an empty string if there is none
+func (e *Error) SpanID() string {
+	var id string
+	walkErrors(e, func(ze *Error) bool {
+		id = ze.spanID
+		return id == ""
+	})
+	return id
+}
+
+// WithTags adds category tags to the error
+func (e *Error) WithTags(tags ...string) *Error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.tags == nil {
+		e.tags = make(map[string]struct{}, len(tags))
+	}
+	for _, tag := range tags {
+		e.tags[tag] = struct{}{}
+	}
+	return e
+}
+
+// HasTag reports whether any error in the chain carries tag
+func (e *Error) HasTag(tag string) bool {
+	found := false
+	walkErrors(e, func(ze *Error) bool {
+		ze.mu.RLock()
+		_, found = ze.tags[tag]
+		ze.mu.RUnlock()
+		return !found
+	})
+	return found
+}
+
+// Tags returns the sorted union of tags across the error chain
+func (e *Error) Tags() []string {
+	seen := make(map[string]struct{})
+	walkErrors(e, func(ze *Error) bool {
+		ze.mu.RLock()
+		for tag := range ze.tags {
+			seen[tag] = struct{}{}
+		}
+		ze.mu.RUnlock()
+		return true
+	})
+	tags := make([]string, 0, len(seen))
+	for tag := range seen {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// AsError returns the first *Error in err's chain
+func AsError(err error) (*Error, bool) {
+	var ze *Error
+	if errors.As(err, &ze) {
+		return ze, true
+	}
+	return nil, false
+}
+
+// SameCode reports whether a and b carry the same non-empty code
+func SameCode(a, b error) bool {
+	code := codeOf(a)
+	return code != "" && code == codeOf(b)
+}
+
+func codeOf(err error) string {
+	var code string
+	walkErrors(err, func(ze *Error) bool {
+		code = ze.code
+		return code == ""
+	})
+	return code
+}
+
+// HasContext reports whether any *Error in err's chain has a context value for key equal to value.
+// It compares the unredacted values and is intended for tests asserting on structured fields.
+func HasContext(err error, key string, value interface{}) bool {
+	found := false
+	walkErrors(err, func(ze *Error) bool {
+		ze.mu.RLock()
+		v, ok := ze.context[key]
+		ze.mu.RUnlock()
+		found = ok && reflect.DeepEqual(v, value)
+		return !found
+	})
+	return found
+}
+
+// HasMessage reports whether any error in err's chain has a message containing substr.
+// Each error's own message is checked, excluding the text of the error it wraps,
+// so an *Error's context and stack trace never match.
+func HasMessage(err error, substr string) bool {
+	found := false
+	Walk(err, func(err error) bool {
+		if ze, ok := err.(*Error); ok {
+			err = ze.err
+		}
+		msg := err.Error()
+		if next := errors.Unwrap(err); next != nil {
+			msg = strings.TrimSuffix(msg, next.Error())
+		}
+		found = strings.Contains(msg, substr)
+		return !found
+	})
+	return found
+}
+
+// EqualsValue reports whether e and other carry the same message, code and context.
+// It is a value comparison intended for tests: stack traces, timestamps and other volatile
+// fields are ignored, and context is compared independently of insertion order.
+func (e *Error) EqualsValue(other *Error) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+	return plainMessage(e) == plainMessage(other) &&
+		e.Code() == other.Code() &&
+		reflect.DeepEqual(e.UnsafeContext(), other.UnsafeContext())
+}
+
+// plainMessage renders err's message chain without the context and stack trace of any *Error in it
+func plainMessage(err error) string {
+	if ze, ok := err.(*Error); ok {
+		return plainMessage(ze.err)
+	}
+	msg := err.Error()
+	next := errors.Unwrap(err)
+	if next == nil || !strings.HasSuffix(msg, next.Error()) {
+		return msg
+	}
+	return strings.TrimSuffix(msg, next.Error()) + plainMessage(next)
+}
+
+// Walk calls fn for each error in err's unwrap chain, outermost first, until fn returns false
+func Walk(err error, fn func(error) bool) {
+	for err != nil {
+		if !fn(err) {
+			return
+		}
+		err = errors.Unwrap(err)
+	}
+}
+
+// Chain returns every error in the unwrap chain, from the outermost to the innermost
+func (e *Error) Chain() []error {
+	var chain []error
+	Walk(e, func(err error) bool {
+		chain = append(chain, err)
+		return true
+	})
+	return chain
+}
+
+// Root returns the innermost error in the unwrap chain
+func (e *Error) Root() error {
+	chain := e.Chain()
+	return chain[len(chain)-1]
+}
+
+// walkErrors calls fn for each *Error in err's unwrap chain, outermost first, until fn returns false.
+// When an *Error has a cause set, the chain of the error its message wraps is visited before the cause.
+func walkErrors(err error, fn func(*Error) bool) {
+	visitErrors(err, fn)
+}
+
+// visitErrors implements walkErrors, reporting false if fn stopped the walk
+func visitErrors(err error, fn func(*Error) bool) bool {
+	completed := true
+	Walk(err, func(err error) bool {
+		ze, ok := err.(*Error)
+		if !ok {
+			return true
+		}
+		if !fn(ze) || (ze.cause != nil && !visitErrors(ze.err, fn)) {
+			completed = false
+		}
+		return completed
+	})
+	return completed
+}
+
+// getStackTrace formats the call stack starting skip frames above its caller
+func getStackTrace(skip int) string {
+	pcs := make([]uintptr, 32)
 	for {
-		n := runtime.Stack(buf, false)
-		if n < len(buf) {
-			return string(buf[:n])
+		n := runtime.Callers(skip+2, pcs)
+		if n < len(pcs) {
+			pcs = pcs[:n]
+			break
 		}
-		buf = make([]byte, 2*len(buf))
+		pcs = make([]uintptr, 2*len(pcs))
 	}
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
 }
 
 // Result represents the outcome of an operation that might fail
@@ -76,49 +852,358 @@ func Err[T any](err error) Result[T] {
 	return Result[T]{err: err}
 }
 
-// Unwrap returns the value if there's no error, otherwise panics
-func (r Result[T]) Unwrap() T {
+// Unwrap returns the value if there's no error, otherwise panics with an *Error that wraps the
+// stored error and records the stack of the Unwrap call
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(created(newError(r.err, 1)))
+	}
+	return r.value
+}
+
+// UnwrapRaw returns the value if there's no error, otherwise panics with the stored error itself.
+// Unlike Unwrap, the panic value is not wrapped in an *Error, so recovering code can compare or
+// type-assert the original error directly.
+func (r Result[T]) UnwrapRaw() T {
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r.value
+}
+
+// UnwrapOr returns the value if there's no error, otherwise returns the default value
+func (r Result[T]) UnwrapOr(defaultValue T) T {
+	if r.err != nil {
+		return defaultValue
+	}
+	return r.value
+}
+
+// UnwrapOrElse returns the value if there's no error, otherwise calls the provided function
+func (r Result[T]) UnwrapOrElse(f func(error) T) T {
+	if r.err != nil {
+		return f(r.err)
+	}
+	return r.value
+}
+
+// UnwrapOrZero returns the value if there's no error, otherwise returns the zero value
+func (r Result[T]) UnwrapOrZero() T {
+	if r.err != nil {
+		var zero T
+		return zero
+	}
+	return r.value
+}
+
+// Map applies a function to the value if there's no error
+func (r Result[T]) Map(f func(T) T) Result[T] {
+	if r.err != nil {
+		return r
+	}
+	return Ok(f(r.value))
+}
+
+// FlatMap applies a function that returns a Result
+func (r Result[T]) FlatMap(f func(T) Result[T]) Result[T] {
+	if r.err != nil {
+		return r
+	}
+	return f(r.value)
+}
+
+// Or returns the Result if there's no error, otherwise returns the alternative Result
+func (r Result[T]) Or(alt Result[T]) Result[T] {
+	if r.err != nil {
+		return alt
+	}
+	return r
+}
+
+// OrElse returns the Result if there's no error, otherwise calls the provided function with the error
+func (r Result[T]) OrElse(f func(error) Result[T]) Result[T] {
+	if r.err != nil {
+		return f(r.err)
+	}
+	return r
+}
+
+// Recover returns the Result if there's no error, otherwise returns Ok with the value f computes from the error
+func (r Result[T]) Recover(f func(error) T) Result[T] {
+	if r.err != nil {
+		return Ok(f(r.err))
+	}
+	return r
+}
+
+// Ensure returns Err with an Error carrying msg and the value under the "value" context key
+// if pred is false for the value, otherwise the Result unchanged
+func (r Result[T]) Ensure(pred func(T) bool, msg string) Result[T] {
+	if r.err != nil || pred(r.value) {
+		return r
+	}
+	return Err[T](created(newError(errors.New(msg), 1).With("value", r.value)))
+}
+
+// Then calls f with the value if there's no error, returning Err if f fails and the Result unchanged otherwise
+func (r Result[T]) Then(f func(T) error) Result[T] {
+	if r.err != nil {
+		return r
+	}
+	if err := f(r.value); err != nil {
+		return Err[T](err)
+	}
+	return r
+}
+
+// Tap calls f with the value if there's no error and returns the Result unchanged
+func (r Result[T]) Tap(f func(T)) Result[T] {
+	if r.err == nil {
+		f(r.value)
+	}
+	return r
+}
+
+// ForEach calls f with the value if there's no error
+func (r Result[T]) ForEach(f func(T)) {
+	if r.err == nil {
+		f(r.value)
+	}
+}
+
+// Invert turns an Ok into an Err and an Err into an Ok.
+// An Ok value is passed to onOk to produce the error; if onOk returns nil an "unexpected success" error is used.
+// An Err's error is passed to onErr to produce the value.
+func (r Result[T]) Invert(onOk func(T) error, onErr func(error) T) Result[T] {
+	if r.err != nil {
+		return Ok(onErr(r.err))
+	}
+	if err := onOk(r.value); err != nil {
+		return Err[T](err)
+	}
+	return Err[T](New("unexpected success").With("value", r.value))
+}
+
+// Match calls ok with the value if there's no error, otherwise calls err with the error
+func Match[T, U any](r Result[T], ok func(T) U, err func(error) U) U {
+	if r.err != nil {
+		return err(r.err)
+	}
+	return ok(r.value)
+}
+
+// MapOr returns f applied to the value if there's no error, otherwise returns def
+func MapOr[T, U any](r Result[T], def U, f func(T) U) U {
+	if r.err != nil {
+		return def
+	}
+	return f(r.value)
+}
+
+// MapOrElse returns f applied to the value if there's no error, otherwise calls def with the error
+func MapOrElse[T, U any](r Result[T], def func(error) U, f func(T) U) U {
+	if r.err != nil {
+		return def(r.err)
+	}
+	return f(r.value)
+}
+
+// Pair holds two values of possibly different types
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip combines two Results into a Result of a Pair, returning the first error encountered
+func Zip[A, B any](a Result[A], b Result[B]) Result[Pair[A, B]] {
+	return ZipWith(a, b, func(first A, second B) Pair[A, B] {
+		return Pair[A, B]{First: first, Second: second}
+	})
+}
+
+// ZipWith combines two Results with f, returning the first error encountered
+func ZipWith[A, B, C any](a Result[A], b Result[B], f func(A, B) C) Result[C] {
+	if a.err != nil {
+		return Err[C](a.err)
+	}
+	if b.err != nil {
+		return Err[C](b.err)
+	}
+	return Ok(f(a.value, b.value))
+}
+
+// Apply calls the function held by rf with the value held by rt.
+// If both are Err, the function's error is returned.
+func Apply[T, U any](rf Result[func(T) U], rt Result[T]) Result[U] {
+	if rf.err != nil {
+		return Err[U](rf.err)
+	}
+	if rt.err != nil {
+		return Err[U](rt.err)
+	}
+	return Ok(rf.value(rt.value))
+}
+
+// Flatten returns the inner Result if the outer Result has no error, otherwise the outer error
+func Flatten[T any](r Result[Result[T]]) Result[T] {
+	if r.err != nil {
+		return Err[T](r.err)
+	}
+	return r.value
+}
+
+// Reduce folds items into an accumulator from left to right, stopping at the first error.
+// The error is wrapped with the index of the failing item.
+func Reduce[T, A any](items []T, initial A, f func(A, T) (A, error)) Result[A] {
+	acc := initial
+	for i, item := range items {
+		next, err := f(acc, item)
+		if err != nil {
+			return Err[A](Wrap(err, fmt.Sprintf("reduce failed at index %d", i)).With("index", i))
+		}
+		acc = next
+	}
+	return Ok(acc)
+}
+
+// ErrFilteredOut is returned by FilterMap when f decides not to keep a value
+var ErrFilteredOut = errors.New("filtered out")
+
+// FilterMap applies f to the value if there's no error. It returns Err with f's error if f fails,
+// Err with ErrFilteredOut if f reports the value should not be kept, and Ok with the mapped value otherwise.
+func FilterMap[T, U any](r Result[T], f func(T) (U, bool, error)) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	value, keep, err := f(r.value)
+	if err != nil {
+		return Err[U](err)
+	}
+	if !keep {
+		return Err[U](ErrFilteredOut)
+	}
+	return Ok(value)
+}
+
+// ErrNoResults is returned by Combine when it is given no Results
+var ErrNoResults = errors.New("no results to combine")
+
+// Combine reduces the values of rs from left to right with reducer, returning the first Err if any.
+// It returns Err with ErrNoResults when rs is empty.
+func Combine[T any](reducer func(T, T) T, rs ...Result[T]) Result[T] {
+	if len(rs) == 0 {
+		return Err[T](ErrNoResults)
+	}
+	if rs[0].err != nil {
+		return rs[0]
+	}
+	acc := rs[0].value
+	for _, r := range rs[1:] {
+		if r.err != nil {
+			return r
+		}
+		acc = reducer(acc, r.value)
+	}
+	return Ok(acc)
+}
+
+// CollectMap applies f to each element and collects the values, stopping at the first Err.
+// The error is wrapped with the index of the failing element.
+func CollectMap[T, U any](in []T, f func(T) Result[U]) Result[[]U] {
+	out := make([]U, 0, len(in))
+	for i, item := range in {
+		r := f(item)
+		if r.err != nil {
+			return Err[[]U](Wrap(r.err, fmt.Sprintf("collect failed at index %d", i)).With("index", i))
+		}
+		out = append(out, r.value)
+	}
+	return Ok(out)
+}
+
+// Partition splits Results into their values and their errors, each in input order
+func Partition[T any](rs []Result[T]) (oks []T, errs []error) {
+	for _, r := range rs {
+		if r.err != nil {
+			errs = append(errs, r.err)
+		} else {
+			oks = append(oks, r.value)
+		}
+	}
+	return oks, errs
+}
+
+// FromChannel drains ch into a slice, blocking until ch is closed
+func FromChannel[T any](ch <-chan Result[T]) []Result[T] {
+	var rs []Result[T]
+	for r := range ch {
+		rs = append(rs, r)
+	}
+	return rs
+}
+
+// Check returns the error if there is one, otherwise returns nil
+func (r Result[T]) Check() error {
+	return r.err
+}
+
+// Get returns the value and a nil error if there's no error, otherwise the zero value and the error
+func (r Result[T]) Get() (T, error) {
 	if r.err != nil {
-		panic(r.err)
+		var zero T
+		return zero, r.err
 	}
-	return r.value
+	return r.value, nil
 }
 
-// UnwrapOr returns the value if there's no error, otherwise returns the default value
-func (r Result[T]) UnwrapOr(defaultValue T) T {
+// Into writes the value and error into the provided pointers. On Err, valuePtr receives the zero value.
+func (r Result[T]) Into(valuePtr *T, errPtr *error) {
 	if r.err != nil {
-		return defaultValue
+		var zero T
+		*valuePtr, *errPtr = zero, r.err
+		return
 	}
-	return r.value
+	*valuePtr, *errPtr = r.value, nil
 }
 
-// UnwrapOrElse returns the value if there's no error, otherwise calls the provided function
-func (r Result[T]) UnwrapOrElse(f func(error) T) T {
+// String returns "Ok(<value>)" or "Err(<error>)", where the error is rendered without context or stack trace
+func (r Result[T]) String() string {
 	if r.err != nil {
-		return f(r.err)
+		return fmt.Sprintf("Err(%s)", plainMessage(r.err))
 	}
-	return r.value
+	return fmt.Sprintf("Ok(%v)", r.value)
 }
 
-// Map applies a function to the value if there's no error
-func (r Result[T]) Map(f func(T) T) Result[T] {
+// ContainsErr reports whether the Result is an Err whose error matches target
+func (r Result[T]) ContainsErr(target error) bool {
+	return r.err != nil && errors.Is(r.err, target)
+}
+
+// OkValue returns the value and true if there's no error, otherwise the zero value and false
+func (r Result[T]) OkValue() (T, bool) {
 	if r.err != nil {
-		return r
+		var zero T
+		return zero, false
 	}
-	return Ok(f(r.value))
+	return r.value, true
 }
 
-// FlatMap applies a function that returns a Result
-func (r Result[T]) FlatMap(f func(T) Result[T]) Result[T] {
+// ErrValue returns the error and true if there is one, otherwise nil and false
+func (r Result[T]) ErrValue() (error, bool) {
+	return r.err, r.err != nil
+}
+
+// AsSlice returns a one-element slice holding the value if there's no error, otherwise an empty slice
+func (r Result[T]) AsSlice() []T {
 	if r.err != nil {
-		return r
+		return []T{}
 	}
-	return f(r.value)
+	return []T{r.value}
 }
 
-// Check returns the error if there is one, otherwise returns nil
-func (r Result[T]) Check() error {
-	return r.err
+// Send sends the Result on ch, blocking until it is received or buffered
+func (r Result[T]) Send(ch chan<- Result[T]) {
+	ch <- r
 }
 
 // Try attempts to execute a function and returns a Result
@@ -130,6 +1215,20 @@ func Try[T any](f func() (T, error)) Result[T] {
 	return Ok(value)
 }
 
+// TryAll runs fns in order and collects their values, stopping at the first error.
+// The error is wrapped with the index of the failing function.
+func TryAll[T any](fns ...func() (T, error)) Result[[]T] {
+	values := make([]T, 0, len(fns))
+	for i, fn := range fns {
+		value, err := fn()
+		if err != nil {
+			return Err[[]T](Wrap(err, fmt.Sprintf("function %d failed", i)).With("index", i))
+		}
+		values = append(values, value)
+	}
+	return Ok(values)
+}
+
 // Must panics if err is not nil, otherwise returns the value
 func Must[T any](value T, err error) T {
 	if err != nil {
@@ -138,6 +1237,27 @@ func Must[T any](value T, err error) T {
 	return value
 }
 
+// MustResult returns the Result's value, or panics like Must with the error wrapped
+// and a stack trace starting at the caller
+func MustResult[T any](r Result[T]) T {
+	if r.err != nil {
+		panic(created(newError(fmt.Errorf("assertion failed: %w", r.err), 1)))
+	}
+	return r.value
+}
+
+// MustCtx is like Must but returns false instead of panicking when ctx has been cancelled
+func MustCtx[T any](ctx context.Context, value T, err error) (T, bool) {
+	if err == nil {
+		return value, true
+	}
+	if ctx.Err() != nil {
+		var zero T
+		return zero, false
+	}
+	panic(Wrap(err, "assertion failed"))
+}
+
 // Handle provides a fluent interface for error handling
 type Handle struct {
 	err error
@@ -152,6 +1272,38 @@ func (h Handle) On(target error, handler func(error)) Handle {
 	return h
 }
 
+// OnAny registers an error handler for errors matching any of the targets
+func (h Handle) OnAny(handler func(error), targets ...error) Handle {
+	if h.err == nil {
+		return h
+	}
+	for _, target := range targets {
+		if errors.Is(h.err, target) {
+			handler(h.err)
+			h.err = nil
+			break
+		}
+	}
+	return h
+}
+
+// OnCode registers an error handler for errors carrying the given code anywhere in their chain
+func (h Handle) OnCode(code string, handler func(error)) Handle {
+	if h.err == nil {
+		return h
+	}
+	matched := false
+	walkErrors(h.err, func(ze *Error) bool {
+		matched = ze.code == code
+		return !matched
+	})
+	if matched {
+		handler(h.err)
+		h.err = nil
+	}
+	return h
+}
+
 // Else handles any remaining error
 func (h Handle) Else(handler func(error)) {
 	if h.err != nil {
@@ -164,49 +1316,533 @@ func Do(f func() error) Handle {
 	return Handle{err: f()}
 }
 
+// DoAll executes functions in order, stopping at the first error, and returns a Handle for that error
+func DoAll(fns ...func() error) Handle {
+	for _, f := range fns {
+		if err := f(); err != nil {
+			return Handle{err: err}
+		}
+	}
+	return Handle{}
+}
+
+// DoCtx executes a function with ctx and returns a Handle for error handling
+func DoCtx(ctx context.Context, f func(context.Context) error) Handle {
+	return Handle{err: f(ctx)}
+}
+
 // Retry retries a function with exponential backoff
 func Retry(ctx context.Context, f func() error, maxRetries int) error {
+	return RetryCtx(ctx, func(context.Context) error { return f() }, maxRetries)
+}
+
+// RetryCtx retries a function with exponential backoff, passing ctx to each attempt
+func RetryCtx(ctx context.Context, f func(ctx context.Context) error, maxRetries int) error {
+	_, err := retryCtx(ctx, f, maxRetries, nil)
+	return err
+}
+
+// RetryCount retries a function like Retry and also returns the number of attempts made,
+// including the successful one
+func RetryCount(ctx context.Context, f func() error, maxRetries int) (int, error) {
+	return retryCtx(ctx, func(context.Context) error { return f() }, maxRetries, nil)
+}
+
+// retryCtx runs the retry loop shared by the Retry variants and returns the number of attempts made.
+// If beforeSleep is not nil it is called after each failed attempt i; a non-nil error from it
+// stops retrying and is returned.
+func retryCtx(ctx context.Context, f func(ctx context.Context) error, maxRetries int, beforeSleep func(i int, err error) error) (int, error) {
 	var err error
 	for i := 0; i < maxRetries; i++ {
-		if err = f(); err == nil {
+		if err = f(ctx); err == nil {
+			return i + 1, nil
+		}
+		if beforeSleep != nil {
+			if err := beforeSleep(i, err); err != nil {
+				return i + 1, err
+			}
+		}
+		if err := sleepCtx(ctx, retryDelay(i)); err != nil {
+			return i + 1, err
+		}
+	}
+	return maxRetries, Wrap(err, fmt.Sprintf("operation failed after %d retries", maxRetries))
+}
+
+// RetryForever calls f until it succeeds or ctx is done, waiting backoff.Next() between attempts.
+// It returns nil on success, after resetting backoff so it can be reused, and ctx.Err() on cancellation.
+func RetryForever(ctx context.Context, f func() error, backoff Backoff) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := f(); err == nil {
+			backoff.Reset()
+			return nil
+		}
+		if err := sleepCtx(ctx, backoff.Next()); err != nil {
+			return err
+		}
+	}
+}
+
+// RetryAttemptTimeout retries a function with exponential backoff, bounding each attempt with its own
+// timeout of perAttempt. An attempt that times out counts as one failed attempt and is retried.
+func RetryAttemptTimeout(ctx context.Context, f func(context.Context) error, maxRetries int, perAttempt time.Duration) error {
+	return RetryCtx(ctx, func(ctx context.Context) error {
+		attemptCtx, cancel := context.WithTimeout(ctx, perAttempt)
+		defer cancel()
+
+		done := make(chan error, 1)
+		go func() { done <- f(attemptCtx) }()
+
+		select {
+		case err := <-done:
+			return err
+		case <-attemptCtx.Done():
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return Wrap(attemptCtx.Err(), "attempt timed out")
+		}
+	}, maxRetries)
+}
+
+// RetryMaxDelay caps the exponential backoff between Retry attempts
+var RetryMaxDelay = 30 * time.Second
+
+// retryDelay returns the backoff before retrying after attempt i: 2^i seconds, capped at RetryMaxDelay.
+// The exponent is clamped before shifting so large attempt counts cannot overflow.
+func retryDelay(i int) time.Duration {
+	if i >= 31 {
+		return RetryMaxDelay
+	}
+	if d := time.Duration(1<<uint(i)) * time.Second; d < RetryMaxDelay {
+		return d
+	}
+	return RetryMaxDelay
+}
+
+// sleepCtx waits for d or until ctx is done. If d would run past ctx's deadline,
+// it only waits until the deadline and returns the context error.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= d {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Poll calls f immediately and then every interval until f reports done, f returns an error, or ctx is done
+func Poll(ctx context.Context, interval time.Duration, f func() (bool, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		done, err := f()
+		if err != nil {
+			return Wrap(err, "poll failed")
+		}
+		if done {
 			return nil
 		}
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-time.After(time.Duration(1<<uint(i)) * time.Second):
+		case <-ticker.C:
+		}
+	}
+}
+
+// Deadline runs f with a context that expires at deadline and returns Err if the deadline passes first.
+// A deadline already in the past returns Err without running f.
+func Deadline[T any](deadline time.Time, f func(context.Context) (T, error)) Result[T] {
+	if !time.Now().Before(deadline) {
+		return Err[T](Wrap(context.DeadlineExceeded, "deadline already passed"))
+	}
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	done := make(chan Result[T], 1)
+	go func() {
+		value, err := f(ctx)
+		if err != nil {
+			done <- Err[T](err)
+			return
+		}
+		done <- Ok(value)
+	}()
+
+	select {
+	case r := <-done:
+		return r
+	case <-ctx.Done():
+		return Err[T](Wrap(ctx.Err(), "deadline exceeded"))
+	}
+}
+
+// ErrInjectedFault is returned by functions wrapped with WithFaultInjection when a fault is injected
+var ErrInjectedFault = errors.New("injected fault")
+
+// WithFaultInjection wraps f so that it fails with ErrInjectedFault for roughly failureRate of calls,
+// using rng to decide so runs are reproducible. A failureRate of 0 returns f unchanged.
+// rng is not safe for concurrent use, so neither is the returned function.
+func WithFaultInjection[T any](f func() (T, error), failureRate float64, rng *rand.Rand) func() (T, error) {
+	if failureRate <= 0 {
+		return f
+	}
+	return func() (T, error) {
+		if rng.Float64() < failureRate {
+			var zero T
+			return zero, ErrInjectedFault
 		}
+		return f()
 	}
-	return Wrap(err, fmt.Sprintf("operation failed after %d retries", maxRetries))
 }
 
 // Group runs functions concurrently and collects their errors
 type Group struct {
-	wg     sync.WaitGroup
-	errMux sync.Mutex
-	errs   []error
+	wg            sync.WaitGroup
+	errMux        sync.Mutex
+	errs          []error
+	sem           chan struct{}
+	ctx           context.Context
+	cancel        context.CancelFunc
+	cancelOnError bool
+	recover       bool
+	expired       atomic.Bool
+}
+
+// NewGroup returns a Group with a context derived from ctx. The context is cancelled on the
+// first error, when ctx's deadline passes or ctx is cancelled, or when Wait returns.
+// Tasks should observe the returned context.
+func NewGroup(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{ctx: ctx, cancel: cancel, cancelOnError: true}, ctx
+}
+
+// NewGroupTimeout returns a Group whose context is cancelled after d, on the first error,
+// or when the returned CancelFunc is called. Tasks should observe the returned context.
+func NewGroupTimeout(parent context.Context, d time.Duration) (*Group, context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(parent, d)
+	return &Group{ctx: ctx, cancel: cancel, cancelOnError: true}, ctx, cancel
+}
+
+// GroupOption configures a Group created by NewGroupWith
+type GroupOption func(*Group)
+
+// WithLimit limits the group to at most n active goroutines, like SetLimit. By default there is no limit.
+func WithLimit(n int) GroupOption {
+	return func(g *Group) { g.SetLimit(n) }
+}
+
+// WithCancelOnError cancels the group's context on the first error. By default the context is only
+// cancelled when ctx is done or Wait returns.
+func WithCancelOnError() GroupOption {
+	return func(g *Group) { g.cancelOnError = true }
+}
+
+// WithRecover converts a panic in a task into an error reported to the group. By default panics propagate.
+func WithRecover() GroupOption {
+	return func(g *Group) { g.recover = true }
+}
+
+// NewGroupWith returns a Group configured by opts with a context derived from ctx.
+// Tasks should observe the returned context.
+func NewGroupWith(ctx context.Context, opts ...GroupOption) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	g := &Group{ctx: ctx, cancel: cancel}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g, ctx
 }
 
-// Go runs the given function in a goroutine
+// SetLimit limits the number of active goroutines in the group to at most n.
+// A negative value indicates no limit. It must not be called while goroutines are active.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go runs the given function in a goroutine, blocking until a slot is free if a limit is set
 func (g *Group) Go(f func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.start(f)
+}
+
+// TryGo runs the given function in a goroutine only if a slot is free, reporting whether it was started
+func (g *Group) TryGo(f func() error) bool {
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	g.start(f)
+	return true
+}
+
+// Slot holds the outcome of a function started with Group.GoResult.
+// It must only be read after the Group's Wait has returned.
+type Slot struct {
+	value interface{}
+	err   error
+}
+
+// Value returns the computed value; callers need a type assertion to use it
+func (s *Slot) Value() interface{} { return s.value }
+
+// Err returns the error returned by the function
+func (s *Slot) Err() error { return s.err }
+
+// GoResult runs the given function in a goroutine and stores its value in the returned Slot
+func (g *Group) GoResult(f func() (interface{}, error)) *Slot {
+	slot := &Slot{}
+	g.Go(func() error {
+		slot.value, slot.err = f()
+		return slot.err
+	})
+	return slot
+}
+
+func (g *Group) start(f func() error) {
 	g.wg.Add(1)
 	go func() {
 		defer g.wg.Done()
-		if err := f(); err != nil {
-			g.errMux.Lock()
-			g.errs = append(g.errs, err)
-			g.errMux.Unlock()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+		err := g.call(f)
+		if g.ctx != nil && errors.Is(g.ctx.Err(), context.DeadlineExceeded) {
+			g.expired.Store(true)
 		}
+		g.Report(err)
 	}()
 }
 
-// Wait waits for all goroutines to complete and returns a combined error
+func (g *Group) call(f func() error) (err error) {
+	if g.recover {
+		defer Recover(&err)
+	}
+	return f()
+}
+
+// Report records an error from a goroutine not started by Go; nil errors are ignored.
+// It is safe for concurrent use, but the caller must ensure Report is called before Wait returns.
+func (g *Group) Report(err error) {
+	if err == nil {
+		return
+	}
+	g.errMux.Lock()
+	g.errs = append(g.errs, err)
+	g.errMux.Unlock()
+	if g.cancelOnError && g.cancel != nil {
+		g.cancel()
+	}
+}
+
+// collected returns a copy of the errors reported so far
+func (g *Group) collected() []error {
+	g.errMux.Lock()
+	defer g.errMux.Unlock()
+	return append([]error(nil), g.errs...)
+}
+
+// containsError reports whether any error in errs matches target
+func containsError(errs []error, target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Wait waits for all goroutines to complete and returns a *MultiError holding every error, in completion order.
+// For a Group with a context, the deadline error is included if the deadline fired while a goroutine
+// was still running and no goroutine already returned it.
 func (g *Group) Wait() error {
 	g.wg.Wait()
-	if len(g.errs) == 0 {
+	errs := g.collected()
+	if g.ctx != nil {
+		if g.expired.Load() && !containsError(errs, context.DeadlineExceeded) {
+			errs = append(errs, g.ctx.Err())
+		}
+		g.cancel()
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return NewMultiError(errs...)
+}
+
+// WaitFirstError waits for all goroutines to complete and returns the first error recorded,
+// by completion time, or nil. For a Group with a context, the deadline error is returned
+// if the deadline fired while a goroutine was still running and no goroutine failed.
+func (g *Group) WaitFirstError() error {
+	g.wg.Wait()
+	if g.ctx != nil {
+		defer g.cancel()
+	}
+	if errs := g.collected(); len(errs) > 0 {
+		return errs[0]
+	}
+	if g.expired.Load() {
+		return g.ctx.Err()
+	}
+	return nil
+}
+
+// WaitContext waits for all goroutines to complete or for ctx to be done, whichever comes first.
+// On timeout it returns a *MultiError holding ctx.Err() followed by any errors collected so far.
+// Goroutines that have not finished keep running in the background.
+func (g *Group) WaitContext(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return g.Wait()
+	case <-ctx.Done():
+		return NewMultiError(append([]error{ctx.Err()}, g.collected()...)...)
+	}
+}
+
+// All runs fns concurrently with ctx and returns each Result in submission order,
+// along with a *MultiError of every failure
+func All[T any](ctx context.Context, fns ...func(context.Context) (T, error)) ([]Result[T], error) {
+	results := make([]Result[T], len(fns))
+	var wg sync.WaitGroup
+	for i, fn := range fns {
+		wg.Add(1)
+		go func(i int, fn func(context.Context) (T, error)) {
+			defer wg.Done()
+			value, err := fn(ctx)
+			if err != nil {
+				results[i] = Err[T](err)
+				return
+			}
+			results[i] = Ok(value)
+		}(i, fn)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+		}
+	}
+	if len(errs) == 0 {
+		return results, nil
+	}
+	return results, NewMultiError(errs...)
+}
+
+// Gather runs fns concurrently and folds each successful value into initial with combine,
+// which is called serially. It returns Err with a *MultiError of every failure, or Ok with the accumulated value.
+// If ctx is done before every function returns, ctx.Err() is included and later results are ignored.
+func Gather[T, A any](ctx context.Context, initial A, combine func(A, T) A, fns ...func() (T, error)) Result[A] {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		acc      = initial
+		errs     []error
+		finished bool
+	)
+	for _, fn := range fns {
+		wg.Add(1)
+		go func(fn func() (T, error)) {
+			defer wg.Done()
+			value, err := fn()
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case finished:
+			case err != nil:
+				errs = append(errs, err)
+			default:
+				acc = combine(acc, value)
+			}
+		}(fn)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	var ctxErr error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		ctxErr = ctx.Err()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	finished = true
+	if ctxErr != nil {
+		errs = append(errs, ctxErr)
+	}
+	if len(errs) > 0 {
+		return Err[A](NewMultiError(errs...))
+	}
+	return Ok(acc)
+}
+
+// FanIn reads every channel until it is closed or ctx is done and returns a *MultiError
+// of all non-nil errors received, or nil. If ctx is done first, ctx.Err() is included.
+func FanIn(ctx context.Context, chans ...<-chan error) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, ch := range chans {
+		wg.Add(1)
+		go func(ch <-chan error) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case err, ok := <-ch:
+					if !ok {
+						return
+					}
+					if err != nil {
+						mu.Lock()
+						errs = append(errs, err)
+						mu.Unlock()
+					}
+				}
+			}
+		}(ch)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
 		return nil
 	}
-	return Wrap(errors.Join(g.errs...), "multiple errors occurred")
+	return NewMultiError(errs...)
 }
 
 // Recover is a function that can be used in a defer statement to recover from panics
@@ -215,3 +1851,157 @@ func Recover(errPtr *error) {
 		*errPtr = Wrap(fmt.Errorf("%v", r), "panic recovered")
 	}
 }
+
+// RecoverExcept is like Recover but re-panics when rethrow reports true for the recovered value
+func RecoverExcept(errPtr *error, rethrow func(recovered interface{}) bool) {
+	if r := recover(); r != nil {
+		if rethrow != nil && rethrow(r) {
+			panic(r)
+		}
+		*errPtr = Wrap(fmt.Errorf("%v", r), "panic recovered")
+	}
+}
+
+// SafeGo runs f in a goroutine, converting any panic into an error passed to onPanic
+func SafeGo(f func(), onPanic func(error)) {
+	go safeRun(f, onPanic)
+}
+
+// safeRun runs f on the calling goroutine, passing any panic to onPanic
+func safeRun(f func(), onPanic func(error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			err := Wrap(fmt.Errorf("%v", r), "panic recovered")
+			if onPanic != nil {
+				onPanic(err)
+			}
+		}
+	}()
+	f()
+}
+
+// Pipeline composes fallible stages that run in order on a single value
+type Pipeline[T any] struct {
+	stages []func(T) (T, error)
+}
+
+// NewPipeline creates an empty Pipeline
+func NewPipeline[T any]() *Pipeline[T] {
+	return &Pipeline[T]{}
+}
+
+// Then appends a stage to the pipeline
+func (p *Pipeline[T]) Then(stage func(T) (T, error)) *Pipeline[T] {
+	p.stages = append(p.stages, stage)
+	return p
+}
+
+// Run passes the input through each stage, stopping at the first error.
+// The error is wrapped with the zero-based index of the failing stage.
+func (p *Pipeline[T]) Run(input T) Result[T] {
+	value := input
+	for i, stage := range p.stages {
+		next, err := stage(value)
+		if err != nil {
+			return Err[T](Wrap(err, fmt.Sprintf("pipeline stage %d failed", i)).With("stage", i))
+		}
+		value = next
+	}
+	return Ok(value)
+}
+
+type contextField struct {
+	name string
+	key  interface{}
+}
+
+var (
+	contextFieldsMux sync.RWMutex
+	contextFields    []contextField
+)
+
+// RegisterContextKey registers a context key whose value WrapContext copies into the error context under name.
+// Registering the same name and key again has no effect. It returns a function that unregisters the
+// pair if this call added it.
+func RegisterContextKey(name string, key interface{}) (restore func()) {
+	contextFieldsMux.Lock()
+	defer contextFieldsMux.Unlock()
+	field := contextField{name: name, key: key}
+	for _, existing := range contextFields {
+		if existing == field {
+			return func() {}
+		}
+	}
+	contextFields = append(contextFields, field)
+	return func() {
+		contextFieldsMux.Lock()
+		defer contextFieldsMux.Unlock()
+		for i, existing := range contextFields {
+			if existing == field {
+				contextFields = append(contextFields[:i:i], contextFields[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// WrapContext wraps an existing error and copies the values of registered context keys from ctx
+func WrapContext(ctx context.Context, err error, message string) *Error {
+	if err == nil {
+		return nil
+	}
+	e := newError(fmt.Errorf("%s: %w", message, err), 1)
+	contextFieldsMux.RLock()
+	for _, field := range contextFields {
+		if value := ctx.Value(field.key); value != nil {
+			e.setContext(field.name, value)
+		}
+	}
+	contextFieldsMux.RUnlock()
+	return created(e)
+}
+
+type errorContextKey struct{}
+
+// ContextWithError returns a copy of ctx carrying err
+func ContextWithError(ctx context.Context, err error) context.Context {
+	return context.WithValue(ctx, errorContextKey{}, err)
+}
+
+// ErrorFromContext returns the error stored in ctx by ContextWithError, or nil if there is none
+func ErrorFromContext(ctx context.Context) error {
+	err, _ := ctx.Value(errorContextKey{}).(error)
+	return err
+}
+
+// Lazy computes a Result on first use and caches it
+type Lazy[T any] struct {
+	once   sync.Once
+	f      func() (T, error)
+	result Result[T]
+}
+
+// NewLazy creates a Lazy that computes its Result with f
+func NewLazy[T any](f func() (T, error)) *Lazy[T] {
+	return &Lazy[T]{f: f}
+}
+
+// Get computes the Result on the first call and returns the cached Result afterwards.
+// It is safe for concurrent use; f runs at most once. A panic in f is cached as an Err.
+func (l *Lazy[T]) Get() Result[T] {
+	l.once.Do(func() {
+		value, err := l.call()
+		if err != nil {
+			l.result = Err[T](err)
+			return
+		}
+		l.result = Ok(value)
+	})
+	return l.result
+}
+
+// call runs f, converting a panic into an error
+func (l *Lazy[T]) call() (value T, err error) {
+	defer Recover(&err)
+	return l.f()
+}
@@ -2,8 +2,17 @@ package safezone
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"net"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -24,6 +33,16 @@ func TestError(t *testing.T) {
 		}
 	})
 
+	t.Run("Newf", func(t *testing.T) {
+		err := Newf("user %d not found", 42)
+		if !strings.Contains(err.Error(), "user 42 not found") {
+			t.Error("Newf should format the message")
+		}
+		if !strings.HasPrefix(err.stackTrace, "github.com/crazywolf132/safezone.TestError") {
+			t.Error("Newf stack trace should start at the caller")
+		}
+	})
+
 	t.Run("Wrap", func(t *testing.T) {
 		originalErr := errors.New("original error")
 		wrappedErr := Wrap(originalErr, "wrapped message")
@@ -41,6 +60,57 @@ func TestError(t *testing.T) {
 			t.Error("Error does not contain added context")
 		}
 	})
+
+	t.Run("WithStack", func(t *testing.T) {
+		if WithStack(nil) != nil {
+			t.Error("WithStack should return nil for a nil error")
+		}
+		err := WithStack(ErrTest)
+		if !errors.Is(err, ErrTest) || err.Unwrap() != ErrTest {
+			t.Error("WithStack should keep the original error")
+		}
+		if !strings.HasPrefix(err.Error(), "test error\n") {
+			t.Error("WithStack should preserve the original message")
+		}
+		if !strings.HasPrefix(err.stackTrace, "github.com/crazywolf132/safezone.TestError") {
+			t.Error("WithStack should capture a stack trace at the caller")
+		}
+	})
+
+	t.Run("Wrapf", func(t *testing.T) {
+		if Wrapf(nil, "user %d", 42) != nil {
+			t.Error("Wrapf should return nil for a nil error")
+		}
+		err := Wrapf(ErrTest, "loading user %d", 42)
+		if !errors.Is(err, ErrTest) {
+			t.Error("Wrapf should wrap the original error")
+		}
+		if !strings.HasPrefix(err.Unwrap().Error(), "loading user 42: test error") {
+			t.Errorf("Unexpected message: %s", err.Unwrap().Error())
+		}
+	})
+
+	t.Run("WrapIf", func(t *testing.T) {
+		fields := map[string]interface{}{"user": "alice", "attempt": 3}
+		if WrapIf(nil, "save failed", fields) != nil {
+			t.Error("WrapIf should return nil for a nil error")
+		}
+		if allocs := testing.AllocsPerRun(100, func() { _ = WrapIf(nil, "save failed", fields) }); allocs != 0 {
+			t.Errorf("WrapIf should not allocate for a nil error, got %v allocations", allocs)
+		}
+
+		err := WrapIf(ErrTest, "save failed", fields)
+		var zerr *Error
+		if !errors.As(err, &zerr) || !errors.Is(err, ErrTest) {
+			t.Fatal("WrapIf should wrap a non-nil error")
+		}
+		if !strings.Contains(err.Error(), "save failed") {
+			t.Error("WrapIf should add the message")
+		}
+		if zerr.Context()["user"] != "alice" || zerr.Context()["attempt"] != 3 {
+			t.Error("WrapIf should apply the fields as context")
+		}
+	})
 }
 
 func TestResult(t *testing.T) {
@@ -73,6 +143,45 @@ func TestResult(t *testing.T) {
 		}
 	})
 
+	t.Run("UnwrapRaw", func(t *testing.T) {
+		if Ok(42).UnwrapRaw() != 42 {
+			t.Error("UnwrapRaw should return the value for Ok results")
+		}
+		defer func() {
+			if r := recover(); r != ErrTest {
+				t.Errorf("UnwrapRaw should panic with the original error, got %v", r)
+			}
+		}()
+		Err[int](ErrTest).UnwrapRaw()
+	})
+
+	t.Run("UnwrapPanic", func(t *testing.T) {
+		defer func() {
+			r := recover()
+			zerr, ok := r.(*Error)
+			if !ok || !errors.Is(zerr, ErrTest) || !strings.Contains(zerr.stackTrace, "TestResult") {
+				t.Errorf("Unwrap should panic with an *Error wrapping the original error, got %v", r)
+			}
+		}()
+		Err[int](ErrTest).Unwrap()
+	})
+
+	t.Run("UnwrapOrZero", func(t *testing.T) {
+		type point struct{ X, Y int }
+		if Ok(42).UnwrapOrZero() != 42 {
+			t.Error("UnwrapOrZero should return the value for Ok results")
+		}
+		if Err[int](ErrTest).UnwrapOrZero() != 0 {
+			t.Error("UnwrapOrZero should return 0 for Err int results")
+		}
+		if Err[string](ErrTest).UnwrapOrZero() != "" {
+			t.Error("UnwrapOrZero should return an empty string for Err string results")
+		}
+		if Err[point](ErrTest).UnwrapOrZero() != (point{}) {
+			t.Error("UnwrapOrZero should return the zero struct for Err struct results")
+		}
+	})
+
 	t.Run("Map", func(t *testing.T) {
 		result := Ok(21).Map(func(i int) int { return i * 2 })
 		if result.Unwrap() != 42 {
@@ -86,6 +195,295 @@ func TestResult(t *testing.T) {
 			t.Error("FlatMap should apply the function to the value")
 		}
 	})
+
+	t.Run("Or", func(t *testing.T) {
+		if Ok(42).Or(Ok(0)).Unwrap() != 42 {
+			t.Error("Or should ignore the alternative for Ok results")
+		}
+		if Err[int](ErrTest).Or(Ok(7)).Unwrap() != 7 {
+			t.Error("Or should return the alternative for Err results")
+		}
+	})
+
+	t.Run("OrElse", func(t *testing.T) {
+		var received error
+		result := Err[int](ErrTest).OrElse(func(err error) Result[int] {
+			received = err
+			return Ok(7)
+		})
+		if received != ErrTest {
+			t.Error("OrElse should pass the original error to the function")
+		}
+		if result.Unwrap() != 7 {
+			t.Error("OrElse should return the computed fallback for Err results")
+		}
+
+		Ok(42).OrElse(func(err error) Result[int] {
+			t.Error("OrElse should not call the function for Ok results")
+			return Ok(0)
+		})
+	})
+
+	t.Run("AsSlice", func(t *testing.T) {
+		if s := Ok(42).AsSlice(); len(s) != 1 || s[0] != 42 {
+			t.Errorf("AsSlice should return the value for Ok results, got %v", s)
+		}
+		if s := Err[int](ErrTest).AsSlice(); s == nil || len(s) != 0 {
+			t.Errorf("AsSlice should return an empty slice for Err results, got %v", s)
+		}
+		var all []int
+		for _, r := range []Result[int]{Ok(1), Err[int](ErrTest), Ok(3)} {
+			all = append(all, r.AsSlice()...)
+		}
+		if len(all) != 2 || all[0] != 1 || all[1] != 3 {
+			t.Errorf("Appending AsSlice should drop failures, got %v", all)
+		}
+	})
+
+	t.Run("Into", func(t *testing.T) {
+		var value int
+		var err error
+		Ok(42).Into(&value, &err)
+		if value != 42 || err != nil {
+			t.Errorf("Into should write the value and a nil error for Ok, got %d %v", value, err)
+		}
+		Err[int](ErrTest).Into(&value, &err)
+		if value != 0 || err != ErrTest {
+			t.Errorf("Into should write the zero value and the error for Err, got %d %v", value, err)
+		}
+	})
+
+	t.Run("Recover", func(t *testing.T) {
+		var received error
+		result := Err[int](ErrTest).Recover(func(err error) int {
+			received = err
+			return 7
+		})
+		if received != ErrTest {
+			t.Error("Recover should pass the original error to the function")
+		}
+		if result.Check() != nil || result.Unwrap() != 7 {
+			t.Error("Recover should convert an Err into an Ok with the recovered value")
+		}
+
+		result = Ok(42).Recover(func(err error) int {
+			t.Error("Recover should not call the function for Ok results")
+			return 0
+		})
+		if result.Unwrap() != 42 {
+			t.Error("Recover should pass Ok results through unchanged")
+		}
+	})
+
+	t.Run("Ensure", func(t *testing.T) {
+		positive := func(i int) bool { return i > 0 }
+		if Ok(42).Ensure(positive, "must be positive").Unwrap() != 42 {
+			t.Error("Ensure should pass values satisfying the predicate through")
+		}
+		result := Ok(-3).Ensure(positive, "must be positive")
+		if !HasMessage(result.Check(), "must be positive") || !HasContext(result.Check(), "value", -3) {
+			t.Errorf("Ensure should return Err carrying the offending value, got %v", result)
+		}
+		if !Err[int](ErrTest).Ensure(func(int) bool {
+			t.Error("Ensure should not call the predicate for Err results")
+			return true
+		}, "unused").ContainsErr(ErrTest) {
+			t.Error("Ensure should pass Err results through")
+		}
+	})
+
+	t.Run("Then", func(t *testing.T) {
+		var seen int
+		result := Ok(42).Then(func(i int) error { seen = i; return nil })
+		if seen != 42 || result.Unwrap() != 42 {
+			t.Error("Then should call the function and keep the value when it succeeds")
+		}
+		if !Ok(42).Then(func(int) error { return ErrTest }).ContainsErr(ErrTest) {
+			t.Error("Then should return Err when the function fails")
+		}
+		if !Err[int](ErrTest).Then(func(int) error {
+			t.Error("Then should not call the function for Err results")
+			return nil
+		}).ContainsErr(ErrTest) {
+			t.Error("Then should pass Err results through")
+		}
+	})
+
+	t.Run("Tap", func(t *testing.T) {
+		var seen int
+		result := Ok(42).Tap(func(i int) { seen = i })
+		if seen != 42 || result.Unwrap() != 42 {
+			t.Error("Tap should call the function and pass the Result through")
+		}
+		Err[int](ErrTest).Tap(func(int) {
+			t.Error("Tap should not call the function for Err results")
+		})
+	})
+
+	t.Run("ForEach", func(t *testing.T) {
+		var calls int
+		Ok(42).ForEach(func(i int) { calls++ })
+		Err[int](ErrTest).ForEach(func(int) { calls++ })
+		if calls != 1 {
+			t.Errorf("ForEach should only run for Ok results, ran %d times", calls)
+		}
+	})
+
+	t.Run("Match", func(t *testing.T) {
+		var okCalls, errCalls int
+		ok := func(i int) string { okCalls++; return "ok" }
+		fail := func(err error) string { errCalls++; return "err" }
+
+		if Match(Ok(42), ok, fail) != "ok" {
+			t.Error("Match should return the ok branch's value for Ok results")
+		}
+		if Match(Err[int](ErrTest), ok, fail) != "err" {
+			t.Error("Match should return the err branch's value for Err results")
+		}
+		if okCalls != 1 || errCalls != 1 {
+			t.Errorf("Match should run exactly one branch per call, got ok=%d err=%d", okCalls, errCalls)
+		}
+	})
+
+	t.Run("ContainsErr", func(t *testing.T) {
+		if !Err[int](Wrap(ErrTest, "wrapped")).ContainsErr(ErrTest) {
+			t.Error("ContainsErr should match a wrapped sentinel")
+		}
+		if Err[int](errors.New("other")).ContainsErr(ErrTest) {
+			t.Error("ContainsErr should not match a different error")
+		}
+		if Ok(42).ContainsErr(ErrTest) {
+			t.Error("ContainsErr should return false for Ok results")
+		}
+	})
+
+	t.Run("MapOr", func(t *testing.T) {
+		toString := func(i int) string { return fmt.Sprint(i) }
+		if MapOr(Ok(42), "none", toString) != "42" {
+			t.Error("MapOr should apply f for Ok results")
+		}
+		if MapOr(Err[int](ErrTest), "none", func(int) string {
+			t.Error("MapOr should not call f for Err results")
+			return ""
+		}) != "none" {
+			t.Error("MapOr should return the default for Err results")
+		}
+	})
+
+	t.Run("MapOrElse", func(t *testing.T) {
+		fallback := func(err error) string { return "failed: " + err.Error() }
+		if MapOrElse(Ok(42), fallback, func(i int) string { return fmt.Sprint(i) }) != "42" {
+			t.Error("MapOrElse should apply f for Ok results")
+		}
+		if MapOrElse(Err[int](ErrTest), fallback, func(int) string { return "" }) != "failed: test error" {
+			t.Error("MapOrElse should compute the default from the error")
+		}
+	})
+
+	t.Run("Zip", func(t *testing.T) {
+		pair := Zip(Ok(42), Ok("answer")).Unwrap()
+		if pair.First != 42 || pair.Second != "answer" {
+			t.Error("Zip should pair the values of two Ok results")
+		}
+
+		errA, errB := errors.New("a"), errors.New("b")
+		if Zip(Err[int](errA), Err[string](errB)).Check() != errA {
+			t.Error("Zip should return the first Result's error")
+		}
+		if Zip(Ok(42), Err[string](errB)).Check() != errB {
+			t.Error("Zip should return the second Result's error")
+		}
+	})
+
+	t.Run("ZipWith", func(t *testing.T) {
+		sum := ZipWith(Ok(40), Ok(2), func(a, b int) int { return a + b })
+		if sum.Unwrap() != 42 {
+			t.Error("ZipWith should combine the values with the function")
+		}
+	})
+
+	t.Run("Invert", func(t *testing.T) {
+		inverted := Ok(42).Invert(func(i int) error { return ErrTest }, func(error) int { return 0 })
+		if !inverted.ContainsErr(ErrTest) {
+			t.Error("Invert should turn an Ok into an Err using onOk")
+		}
+
+		recovered := Err[int](ErrTest).Invert(func(int) error { return nil }, func(err error) int { return 7 })
+		if recovered.Unwrap() != 7 {
+			t.Error("Invert should turn an Err into an Ok using onErr")
+		}
+
+		if Ok(42).Invert(func(int) error { return nil }, func(error) int { return 0 }).Check() == nil {
+			t.Error("Invert should still produce an Err when onOk returns nil")
+		}
+	})
+
+	t.Run("OkValue", func(t *testing.T) {
+		if v, ok := Ok(42).OkValue(); !ok || v != 42 {
+			t.Error("OkValue should return the value and true for Ok results")
+		}
+		if v, ok := Err[int](ErrTest).OkValue(); ok || v != 0 {
+			t.Error("OkValue should return the zero value and false for Err results")
+		}
+	})
+
+	t.Run("ErrValue", func(t *testing.T) {
+		if err, ok := Err[int](ErrTest).ErrValue(); !ok || err != ErrTest {
+			t.Error("ErrValue should return the error and true for Err results")
+		}
+		if err, ok := Ok(42).ErrValue(); ok || err != nil {
+			t.Error("ErrValue should return nil and false for Ok results")
+		}
+	})
+
+	t.Run("Flatten", func(t *testing.T) {
+		if Flatten(Ok(Ok(42))).Unwrap() != 42 {
+			t.Error("Flatten should return the inner value for Ok(Ok)")
+		}
+		if !Flatten(Ok(Err[int](ErrTest))).ContainsErr(ErrTest) {
+			t.Error("Flatten should return the inner error for Ok(Err)")
+		}
+		if !Flatten(Err[Result[int]](ErrTest)).ContainsErr(ErrTest) {
+			t.Error("Flatten should propagate the outer error")
+		}
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		double := Ok(func(i int) int { return i * 2 })
+		if Apply(double, Ok(21)).Unwrap() != 42 {
+			t.Error("Apply should call the function with the value")
+		}
+
+		errF, errV := errors.New("function"), errors.New("value")
+		if Apply(Err[func(int) int](errF), Err[int](errV)).Check() != errF {
+			t.Error("Apply should return the function's error first")
+		}
+		if Apply(double, Err[int](errV)).Check() != errV {
+			t.Error("Apply should return the value's error")
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		if v, err := Ok(42).Get(); err != nil || v != 42 {
+			t.Error("Get should return the value and nil for Ok results")
+		}
+		if v, err := Err[int](ErrTest).Get(); err != ErrTest || v != 0 {
+			t.Error("Get should return the zero value and the error for Err results")
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		if s := fmt.Sprint(Ok(42)); s != "Ok(42)" {
+			t.Errorf("Expected Ok(42), got %s", s)
+		}
+		if s := fmt.Sprintf("%v", Err[int](ErrTest)); s != "Err(test error)" {
+			t.Errorf("Expected Err(test error), got %s", s)
+		}
+		failed := Try(func() (int, error) { return 0, Wrap(ErrTest, "load failed").With("id", 7) })
+		if s := failed.String(); s != "Err(operation failed: load failed: test error)" {
+			t.Errorf("String should omit context and stack trace, got %s", s)
+		}
+	})
 }
 
 func TestHandle(t *testing.T) {
@@ -133,6 +531,83 @@ func TestHandle(t *testing.T) {
 			t.Error("Subsequent matching On should not handle the error")
 		}
 	})
+
+	t.Run("OnCode", func(t *testing.T) {
+		var handled bool
+		Do(func() error {
+			return Wrap(New("user missing").WithCode("not_found"), "lookup failed")
+		}).OnCode("forbidden", func(err error) {
+			t.Error("OnCode should not handle a different code")
+		}).OnCode("not_found", func(err error) {
+			handled = true
+		}).Else(func(err error) {
+			t.Error("Else should not be called once the error is handled")
+		})
+		if !handled {
+			t.Error("OnCode should handle a wrapped error carrying the code")
+		}
+	})
+
+	t.Run("DoCtx", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var handled bool
+		DoCtx(ctx, func(ctx context.Context) error {
+			return ctx.Err()
+		}).On(context.Canceled, func(err error) {
+			handled = true
+		}).Else(func(err error) {
+			t.Error("Else should not be called once the error is handled")
+		})
+		if !handled {
+			t.Error("On should handle the context error returned by the function")
+		}
+	})
+
+	t.Run("DoAll", func(t *testing.T) {
+		var ran []int
+		step := func(n int, err error) func() error {
+			return func() error {
+				ran = append(ran, n)
+				return err
+			}
+		}
+		var handled error
+		DoAll(step(1, nil), step(2, ErrTest), step(3, nil)).On(ErrTest, func(err error) {
+			handled = err
+		})
+		if len(ran) != 2 || ran[1] != 2 {
+			t.Errorf("DoAll should stop at the first failure, ran %v", ran)
+		}
+		if handled != ErrTest {
+			t.Error("DoAll should carry the first error into the Handle")
+		}
+
+		DoAll(step(4, nil)).Else(func(error) {
+			t.Error("DoAll should not handle anything when every function succeeds")
+		})
+	})
+
+	t.Run("OnAny", func(t *testing.T) {
+		errA, errB := errors.New("a"), errors.New("b")
+		var calls int
+		Do(func() error {
+			return fmt.Errorf("wrapped: %w", errB)
+		}).OnAny(func(error) { calls++ }, errA, errB, ErrTest).Else(func(error) {
+			t.Error("OnAny should consume a matching error")
+		})
+		if calls != 1 {
+			t.Errorf("OnAny should handle a match exactly once, got %d calls", calls)
+		}
+
+		var unhandled error
+		Do(func() error { return ErrTest }).OnAny(func(error) {
+			t.Error("OnAny should not handle errors matching none of the targets")
+		}, errA, errB).Else(func(err error) { unhandled = err })
+		if unhandled != ErrTest {
+			t.Error("Unmatched errors should pass through to Else")
+		}
+	})
 }
 
 func TestGroup(t *testing.T) {
@@ -157,69 +632,424 @@ func TestGroup(t *testing.T) {
 			t.Error("Combined error should contain all error messages")
 		}
 	})
-}
 
-func TestTry(t *testing.T) {
-	t.Run("Success", func(t *testing.T) {
-		result := Try(func() (int, error) { return 42, nil })
-		if result.Check() != nil {
-			t.Error("Try should return Ok result for successful operations")
+	t.Run("WaitContextTimeout", func(t *testing.T) {
+		var g Group
+		block := make(chan struct{})
+		defer close(block)
+		g.Go(func() error { return nil })
+		g.Go(func() error {
+			<-block
+			return nil
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err := g.WaitContext(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Error("WaitContext should return a deadline error when ctx expires")
 		}
-		if result.Unwrap() != 42 {
-			t.Error("Try should return correct value for successful operations")
+		if time.Since(start) > time.Second {
+			t.Error("WaitContext should return promptly after ctx expires")
 		}
 	})
 
-	t.Run("Failure", func(t *testing.T) {
-		result := Try(func() (int, error) { return 0, errors.New("test error") })
-		if result.Check() == nil {
-			t.Error("Try should return Err result for failed operations")
+	t.Run("WaitContextComplete", func(t *testing.T) {
+		var g Group
+		g.Go(func() error { return ErrTest })
+		err := g.WaitContext(context.Background())
+		if !errors.Is(err, ErrTest) {
+			t.Error("WaitContext should return collected errors when all goroutines finish")
 		}
 	})
-}
 
-func TestMust(t *testing.T) {
-	t.Run("Success", func(t *testing.T) {
-		value := Must(42, nil)
-		if value != 42 {
-			t.Error("Must should return the value when no error occurs")
+	t.Run("TryGo", func(t *testing.T) {
+		var g Group
+		g.SetLimit(1)
+		release := make(chan struct{})
+		if !g.TryGo(func() error {
+			<-release
+			return nil
+		}) {
+			t.Error("TryGo should start a goroutine when a slot is free")
+		}
+		if g.TryGo(func() error {
+			t.Error("TryGo should not run the function when the limit is saturated")
+			return nil
+		}) {
+			t.Error("TryGo should return false when the limit is saturated")
+		}
+		close(release)
+		if err := g.Wait(); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if !g.TryGo(func() error { return nil }) {
+			t.Error("TryGo should succeed once a slot is released")
 		}
+		g.Wait()
 	})
 
-	t.Run("Failure", func(t *testing.T) {
-		defer func() {
-			if r := recover(); r == nil {
-				t.Error("Must should panic when an error occurs")
-			}
-		}()
-		Must(0, errors.New("test error"))
+	t.Run("GoResult", func(t *testing.T) {
+		var g Group
+		name := g.GoResult(func() (interface{}, error) { return "alice", nil })
+		age := g.GoResult(func() (interface{}, error) { return 30, nil })
+		failed := g.GoResult(func() (interface{}, error) { return nil, ErrTest })
+		if err := g.Wait(); !errors.Is(err, ErrTest) {
+			t.Error("Wait should still collect errors from GoResult")
+		}
+		if name.Value().(string) != "alice" || age.Value().(int) != 30 {
+			t.Error("Slots should hold the computed values after Wait")
+		}
+		if failed.Err() != ErrTest {
+			t.Error("Slot should hold the function's error")
+		}
 	})
-}
 
-func TestRetry(t *testing.T) {
-	t.Run("EventualSuccess", func(t *testing.T) {
-		attempts := 0
-		err := Retry(context.Background(), func() error {
-			attempts++
-			if attempts < 3 {
-				return errors.New("temporary error")
-			}
-			return nil
-		}, 5)
-		if err != nil {
-			t.Error("Retry should eventually succeed")
+	t.Run("Report", func(t *testing.T) {
+		var g Group
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				g.Report(fmt.Errorf("external error %d", i))
+			}(i)
 		}
-		if attempts != 3 {
-			t.Errorf("Expected 3 attempts, got %d", attempts)
+		wg.Wait()
+		g.Report(nil)
+		err := g.Wait()
+		for i := 0; i < 3; i++ {
+			if !strings.Contains(err.Error(), fmt.Sprintf("external error %d", i)) {
+				t.Errorf("Wait should join reported error %d", i)
+			}
 		}
 	})
 
-	t.Run("Failure", func(t *testing.T) {
-		err := Retry(context.Background(), func() error {
-			return errors.New("persistent error")
-		}, 3)
-		if err == nil {
-			t.Error("Retry should fail after max attempts")
+	t.Run("ReportDuringWait", func(t *testing.T) {
+		var g Group
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 100; i++ {
+				g.Report(fmt.Errorf("late error %d", i))
+			}
+		}()
+		for i := 0; i < 100; i++ {
+			_ = g.Wait()
+			_ = g.WaitFirstError()
+		}
+		<-done
+		if err := g.Wait(); err == nil || len(err.(*MultiError).Errors()) != 100 {
+			t.Errorf("Wait should see every reported error, got %v", err)
+		}
+	})
+
+	t.Run("WaitFirstError", func(t *testing.T) {
+		errSlow, errFast := errors.New("slow"), errors.New("fast")
+		var g Group
+		g.Go(func() error {
+			time.Sleep(50 * time.Millisecond)
+			return errSlow
+		})
+		g.Go(func() error { return errFast })
+		if err := g.WaitFirstError(); err != errFast {
+			t.Errorf("WaitFirstError should return the faster failure, got %v", err)
+		}
+
+		var ok Group
+		ok.Go(func() error { return nil })
+		if err := ok.WaitFirstError(); err != nil {
+			t.Errorf("WaitFirstError should return nil when nothing failed, got %v", err)
+		}
+	})
+
+	t.Run("ParentDeadline", func(t *testing.T) {
+		parent, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		g, ctx := NewGroup(parent)
+		var observed int32
+		for i := 0; i < 3; i++ {
+			g.Go(func() error {
+				select {
+				case <-ctx.Done():
+					atomic.AddInt32(&observed, 1)
+					return ctx.Err()
+				case <-time.After(time.Second):
+					return nil
+				}
+			})
+		}
+		err := g.Wait()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Error("Wait should report the parent deadline")
+		}
+		if observed != 3 {
+			t.Errorf("Expected every task to observe cancellation, got %d", observed)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		g, ctx, cancel := NewGroupTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		var fastDone bool
+		g.Go(func() error {
+			fastDone = true
+			return nil
+		})
+		g.Go(func() error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second):
+				return nil
+			}
+		})
+		start := time.Now()
+		err := g.Wait()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Error("Wait should report the timeout when the deadline fires")
+		}
+		if time.Since(start) > 500*time.Millisecond {
+			t.Error("The slow task should be cancelled by the timeout")
+		}
+		if !fastDone {
+			t.Error("The fast task should complete")
+		}
+	})
+
+	t.Run("TimeoutAfterCompletion", func(t *testing.T) {
+		g, _, cancel := NewGroupTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		g.Go(func() error { return nil })
+		time.Sleep(50 * time.Millisecond)
+		if err := g.Wait(); err != nil {
+			t.Errorf("Wait should not report a deadline that fired after every task finished, got %v", err)
+		}
+	})
+
+	t.Run("TimeoutNotDuplicated", func(t *testing.T) {
+		g, ctx, cancel := NewGroupTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		g.Go(func() error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		err := g.Wait()
+		var merr *MultiError
+		if !errors.As(err, &merr) || len(merr.Errors()) != 1 {
+			t.Errorf("Wait should report the deadline once, got %v", err)
+		}
+	})
+
+	t.Run("TimeoutCancelOnError", func(t *testing.T) {
+		g, ctx, cancel := NewGroupTimeout(context.Background(), time.Second)
+		defer cancel()
+		g.Go(func() error { return ErrTest })
+		g.Go(func() error {
+			<-ctx.Done()
+			return nil
+		})
+		err := g.Wait()
+		if !errors.Is(err, ErrTest) || errors.Is(err, context.DeadlineExceeded) {
+			t.Error("Wait should report the first error without a timeout")
+		}
+	})
+
+	t.Run("NewGroupWith", func(t *testing.T) {
+		g, ctx := NewGroupWith(context.Background(), WithLimit(2), WithCancelOnError())
+		var active, maxActive, cancelled int32
+		for i := 0; i < 6; i++ {
+			i := i
+			g.Go(func() error {
+				n := atomic.AddInt32(&active, 1)
+				defer atomic.AddInt32(&active, -1)
+				for {
+					max := atomic.LoadInt32(&maxActive)
+					if n <= max || atomic.CompareAndSwapInt32(&maxActive, max, n) {
+						break
+					}
+				}
+				if i == 0 {
+					return ErrTest
+				}
+				select {
+				case <-ctx.Done():
+					atomic.AddInt32(&cancelled, 1)
+					return nil
+				case <-time.After(time.Second):
+					return nil
+				}
+			})
+		}
+		if err := g.Wait(); !errors.Is(err, ErrTest) {
+			t.Errorf("Expected the task error, got %v", err)
+		}
+		if maxActive > 2 {
+			t.Errorf("WithLimit should cap active tasks at 2, saw %d", maxActive)
+		}
+		if cancelled != 5 {
+			t.Errorf("WithCancelOnError should cancel the remaining tasks, %d observed it", cancelled)
+		}
+	})
+
+	t.Run("NewGroupWithDefaults", func(t *testing.T) {
+		g, ctx := NewGroupWith(context.Background())
+		g.Go(func() error { return ErrTest })
+		g.Go(func() error {
+			time.Sleep(20 * time.Millisecond)
+			if ctx.Err() != nil {
+				t.Error("The context should not be cancelled on error by default")
+			}
+			return nil
+		})
+		g.Wait()
+		if ctx.Err() == nil {
+			t.Error("The context should be cancelled once Wait returns")
+		}
+	})
+
+	t.Run("WithRecover", func(t *testing.T) {
+		g, _ := NewGroupWith(context.Background(), WithRecover())
+		g.Go(func() error { panic("boom") })
+		if err := g.Wait(); err == nil || !strings.Contains(err.Error(), "boom") {
+			t.Errorf("WithRecover should report a panic as an error, got %v", err)
+		}
+	})
+}
+
+func TestTry(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		result := Try(func() (int, error) { return 42, nil })
+		if result.Check() != nil {
+			t.Error("Try should return Ok result for successful operations")
+		}
+		if result.Unwrap() != 42 {
+			t.Error("Try should return correct value for successful operations")
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		result := Try(func() (int, error) { return 0, errors.New("test error") })
+		if result.Check() == nil {
+			t.Error("Try should return Err result for failed operations")
+		}
+	})
+}
+
+func TestTryAll(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		values := TryAll(
+			func() (int, error) { return 1, nil },
+			func() (int, error) { return 2, nil },
+		).Unwrap()
+		if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+			t.Errorf("Expected [1 2], got %v", values)
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		result := TryAll(
+			func() (int, error) { return 1, nil },
+			func() (int, error) { return 0, ErrTest },
+			func() (int, error) { t.Error("TryAll should stop at the first error"); return 3, nil },
+		)
+		var zerr *Error
+		if !errors.As(result.Check(), &zerr) || !errors.Is(zerr, ErrTest) {
+			t.Fatal("TryAll should return the failing function's error")
+		}
+		if zerr.Context()["index"] != 1 {
+			t.Error("TryAll error should record the failing index")
+		}
+	})
+}
+
+func TestMust(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		value := Must(42, nil)
+		if value != 42 {
+			t.Error("Must should return the value when no error occurs")
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("Must should panic when an error occurs")
+			}
+		}()
+		Must(0, errors.New("test error"))
+	})
+
+	t.Run("MustResult", func(t *testing.T) {
+		if MustResult(Ok(42)) != 42 {
+			t.Error("MustResult should return the value for Ok results")
+		}
+
+		defer func() {
+			ze, ok := recover().(*Error)
+			if !ok {
+				t.Fatal("MustResult should panic with an *Error for Err results")
+			}
+			if !errors.Is(ze, ErrTest) {
+				t.Error("The panic value should wrap the original error")
+			}
+			if !strings.HasPrefix(ze.stackTrace, "github.com/crazywolf132/safezone.TestMust") {
+				t.Errorf("The stack trace should start at the call site, got:\n%s", ze.stackTrace)
+			}
+		}()
+		MustResult(Err[int](ErrTest))
+	})
+
+	t.Run("MustCtxSuccess", func(t *testing.T) {
+		value, ok := MustCtx(context.Background(), 42, nil)
+		if !ok || value != 42 {
+			t.Error("MustCtx should return the value when no error occurs")
+		}
+	})
+
+	t.Run("MustCtxCancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		value, ok := MustCtx(ctx, 42, context.Canceled)
+		if ok || value != 0 {
+			t.Error("MustCtx should return the zero value and false when ctx is cancelled")
+		}
+	})
+
+	t.Run("MustCtxFailure", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("MustCtx should panic when an error occurs and ctx is not cancelled")
+			}
+		}()
+		MustCtx(context.Background(), 0, errors.New("test error"))
+	})
+}
+
+func TestRetry(t *testing.T) {
+	t.Run("EventualSuccess", func(t *testing.T) {
+		attempts := 0
+		err := Retry(context.Background(), func() error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("temporary error")
+			}
+			return nil
+		}, 5)
+		if err != nil {
+			t.Error("Retry should eventually succeed")
+		}
+		if attempts != 3 {
+			t.Errorf("Expected 3 attempts, got %d", attempts)
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		err := Retry(context.Background(), func() error {
+			return errors.New("persistent error")
+		}, 3)
+		if err == nil {
+			t.Error("Retry should fail after max attempts")
 		}
 	})
 
@@ -237,6 +1067,173 @@ func TestRetry(t *testing.T) {
 			t.Error("Retry should respect context cancellation")
 		}
 	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err := Retry(ctx, func() error {
+			return errors.New("temporary error")
+		}, 5)
+		elapsed := time.Since(start)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Error("Retry should return the deadline error")
+		}
+		if elapsed > 500*time.Millisecond {
+			t.Errorf("Retry should return near the deadline, took %v", elapsed)
+		}
+	})
+
+	t.Run("RetryCtx", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+		err := RetryCtx(ctx, func(ctx context.Context) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			attempts++
+			cancel()
+			return errors.New("temporary error")
+		}, 5)
+		if !errors.Is(err, context.Canceled) {
+			t.Error("RetryCtx should return the context error when cancelled mid-retry")
+		}
+		if attempts != 1 {
+			t.Errorf("Expected 1 attempt, got %d", attempts)
+		}
+	})
+
+	t.Run("AttemptTimeout", func(t *testing.T) {
+		var attempts int32
+		start := time.Now()
+		err := RetryAttemptTimeout(context.Background(), func(ctx context.Context) error {
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			return nil
+		}, 3, 50*time.Millisecond)
+		if err != nil {
+			t.Errorf("Expected success on the second attempt, got %v", err)
+		}
+		if atomic.LoadInt32(&attempts) != 2 {
+			t.Errorf("Expected 2 attempts, got %d", attempts)
+		}
+		if time.Since(start) > 2*time.Second {
+			t.Error("A timed-out attempt should not block the retry loop")
+		}
+	})
+
+	t.Run("AttemptTimeoutExhausted", func(t *testing.T) {
+		hang := make(chan struct{})
+		defer close(hang)
+		err := RetryAttemptTimeout(context.Background(), func(context.Context) error {
+			<-hang
+			return nil
+		}, 1, 20*time.Millisecond)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected a deadline error when every attempt times out, got %v", err)
+		}
+	})
+
+	t.Run("DelayCap", func(t *testing.T) {
+		for i := 0; i < 35; i++ {
+			if d := retryDelay(i); d <= 0 || d > RetryMaxDelay {
+				t.Fatalf("Delay for attempt %d should be positive and capped, got %v", i, d)
+			}
+		}
+		if retryDelay(3) != 8*time.Second {
+			t.Errorf("Expected 8s before the cap, got %v", retryDelay(3))
+		}
+		if retryDelay(34) != RetryMaxDelay {
+			t.Errorf("Expected the cap for large attempts, got %v", retryDelay(34))
+		}
+
+		defer func(old time.Duration) { RetryMaxDelay = old }(RetryMaxDelay)
+		RetryMaxDelay = 2 * time.Second
+		if retryDelay(5) != 2*time.Second {
+			t.Error("retryDelay should honor a configured RetryMaxDelay")
+		}
+	})
+
+	t.Run("RetryCount", func(t *testing.T) {
+		defer func(old time.Duration) { RetryMaxDelay = old }(RetryMaxDelay)
+		RetryMaxDelay = time.Millisecond
+
+		calls := 0
+		count, err := RetryCount(context.Background(), func() error {
+			calls++
+			if calls < 3 {
+				return ErrTest
+			}
+			return nil
+		}, 5)
+		if err != nil || count != 3 || count != calls {
+			t.Errorf("Expected 3 attempts and success, got %d attempts and %v", count, err)
+		}
+
+		calls = 0
+		count, err = RetryCount(context.Background(), func() error {
+			calls++
+			return ErrTest
+		}, 4)
+		if !errors.Is(err, ErrTest) || count != 4 || count != calls {
+			t.Errorf("Expected 4 attempts and the final error, got %d attempts and %v", count, err)
+		}
+	})
+
+	t.Run("RetryForever", func(t *testing.T) {
+		backoff := NewExponential(time.Millisecond, 5*time.Millisecond, 2)
+		calls := 0
+		err := RetryForever(context.Background(), func() error {
+			calls++
+			if calls < 10 {
+				return ErrTest
+			}
+			return nil
+		}, backoff)
+		if err != nil || calls != 10 {
+			t.Errorf("Expected success after 10 attempts, got %d attempts and %v", calls, err)
+		}
+		if backoff.Next() != time.Millisecond {
+			t.Error("RetryForever should reset the backoff after a success")
+		}
+	})
+
+	t.Run("RetryForeverCancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			cancel()
+		}()
+		start := time.Now()
+		err := RetryForever(ctx, func() error { return ErrTest }, NewExponential(time.Hour, time.Hour, 1))
+		if err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if time.Since(start) > time.Second {
+			t.Error("RetryForever should stop during the backoff when ctx is cancelled")
+		}
+	})
+
+	t.Run("AttemptTimeoutParentCancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		hang := make(chan struct{})
+		defer close(hang)
+		attempts := 0
+		err := RetryAttemptTimeout(ctx, func(context.Context) error {
+			attempts++
+			cancel()
+			<-hang
+			return nil
+		}, 3, time.Minute)
+		if err != context.Canceled {
+			t.Errorf("Parent cancellation should be returned unwrapped, got %v", err)
+		}
+		if attempts != 1 {
+			t.Errorf("Parent cancellation should stop retrying, got %d attempts", attempts)
+		}
+	})
 }
 
 func TestRecover(t *testing.T) {
@@ -264,4 +1261,1316 @@ func TestRecover(t *testing.T) {
 			t.Error("Recover should not set error when no panic occurs")
 		}
 	})
+
+	t.Run("RecoverExceptConverts", func(t *testing.T) {
+		var err error
+		func() {
+			defer RecoverExcept(&err, isRuntimeError)
+			panic("test panic")
+		}()
+		if err == nil || !strings.Contains(err.Error(), "test panic") {
+			t.Error("RecoverExcept should convert panics the predicate rejects")
+		}
+	})
+
+	t.Run("RecoverExceptRethrows", func(t *testing.T) {
+		var err error
+		defer func() {
+			r := recover()
+			if _, ok := r.(runtime.Error); !ok {
+				t.Error("RecoverExcept should re-panic with the runtime error")
+			}
+			if err != nil {
+				t.Error("RecoverExcept should not set the error when re-panicking")
+			}
+		}()
+		func() {
+			defer RecoverExcept(&err, isRuntimeError)
+			var m map[string]int
+			m["boom"] = 1
+		}()
+	})
+}
+
+func isRuntimeError(recovered interface{}) bool {
+	_, ok := recovered.(runtime.Error)
+	return ok
+}
+
+func TestSafeGo(t *testing.T) {
+	t.Run("RecoverPanic", func(t *testing.T) {
+		errCh := make(chan error, 1)
+		SafeGo(func() {
+			panic("background panic")
+		}, func(err error) {
+			errCh <- err
+		})
+		select {
+		case err := <-errCh:
+			if !strings.Contains(err.Error(), "background panic") {
+				t.Error("Recovered error should contain panic message")
+			}
+		case <-time.After(time.Second):
+			t.Error("onPanic should be called when the goroutine panics")
+		}
+	})
+
+	t.Run("NilHandler", func(t *testing.T) {
+		// Run the SafeGo body directly so the test waits until the recovery path has returned
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			safeRun(func() { panic("dropped panic") }, nil)
+		}()
+		<-done
+	})
+}
+
+func TestPipeline(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		result := NewPipeline[int]().
+			Then(func(i int) (int, error) { return i + 1, nil }).
+			Then(func(i int) (int, error) { return i * 2, nil }).
+			Then(func(i int) (int, error) { return i * 3, nil }).
+			Run(6)
+		if result.Unwrap() != 42 {
+			t.Error("Pipeline should apply every stage in order")
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		var ranFourth bool
+		result := NewPipeline[int]().
+			Then(func(i int) (int, error) { return i + 1, nil }).
+			Then(func(i int) (int, error) { return i + 1, nil }).
+			Then(func(i int) (int, error) { return 0, ErrTest }).
+			Then(func(i int) (int, error) { ranFourth = true; return i, nil }).
+			Run(0)
+		if !errors.Is(result.Check(), ErrTest) {
+			t.Error("Pipeline should return the failing stage's error")
+		}
+		if ranFourth {
+			t.Error("Pipeline should stop at the first failing stage")
+		}
+		var zerr *Error
+		if !errors.As(result.Check(), &zerr) || zerr.context["stage"] != 2 {
+			t.Error("Pipeline error should record the failing stage index")
+		}
+	})
+}
+
+func TestSeverity(t *testing.T) {
+	t.Run("Explicit", func(t *testing.T) {
+		err := New("disk almost full").WithSeverity(SeverityWarn)
+		if err.Severity() != SeverityWarn {
+			t.Errorf("Expected severity %v, got %v", SeverityWarn, err.Severity())
+		}
+	})
+
+	t.Run("Inherited", func(t *testing.T) {
+		inner := New("database down").WithSeverity(SeverityFatal)
+		outer := Wrap(inner, "request failed").WithSeverity(SeverityWarn)
+		if outer.Severity() != SeverityFatal {
+			t.Errorf("Expected highest severity %v, got %v", SeverityFatal, outer.Severity())
+		}
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		if New("plain").Severity() != SeverityError {
+			t.Error("Errors without an explicit severity should default to SeverityError")
+		}
+	})
+
+	t.Run("PanicIfFatal", func(t *testing.T) {
+		fatal := Wrap(New("database down").WithSeverity(SeverityFatal), "request failed")
+		if !fatal.IsFatal() {
+			t.Error("IsFatal should detect a fatal error in the chain")
+		}
+		func() {
+			defer func() {
+				if r := recover(); r != fatal {
+					t.Errorf("PanicIfFatal should panic with the fatal error, got %v", r)
+				}
+			}()
+			PanicIfFatal(fatal)
+		}()
+
+		warn := New("disk almost full").WithSeverity(SeverityWarn)
+		if warn.IsFatal() {
+			t.Error("IsFatal should be false for a warning")
+		}
+		PanicIfFatal(warn)
+		PanicIfFatal(errors.New("plain"))
+		PanicIfFatal(nil)
+	})
+}
+
+func TestStatus(t *testing.T) {
+	t.Run("Explicit", func(t *testing.T) {
+		err := New("user not found").WithStatus(404)
+		if err.Status() != 404 {
+			t.Errorf("Expected status 404, got %d", err.Status())
+		}
+	})
+
+	t.Run("Inherited", func(t *testing.T) {
+		inner := New("user not found").WithStatus(404)
+		outer := Wrap(inner, "lookup failed")
+		if StatusOf(outer) != 404 {
+			t.Errorf("Expected inherited status 404, got %d", StatusOf(outer))
+		}
+		if outer.WithStatus(400).Status() != 400 {
+			t.Error("Outer status should override the inner status")
+		}
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		if StatusOf(errors.New("plain")) != 500 {
+			t.Error("Plain errors should default to status 500")
+		}
+	})
+}
+
+func TestRedaction(t *testing.T) {
+	t.Run("WithSecret", func(t *testing.T) {
+		err := New("auth failed").With("user", "alice").WithSecret("token", "s3cr3t")
+		if strings.Contains(err.Error(), "s3cr3t") {
+			t.Error("Secret values should not appear in the formatted error")
+		}
+		if !strings.Contains(err.Error(), "alice") {
+			t.Error("Normal values should appear in the formatted error")
+		}
+		data, jsonErr := json.Marshal(err)
+		if jsonErr != nil {
+			t.Fatalf("Unexpected marshal error: %v", jsonErr)
+		}
+		if strings.Contains(string(data), "s3cr3t") {
+			t.Error("Secret values should not appear in the JSON output")
+		}
+		if err.UnsafeContext()["token"] != "s3cr3t" {
+			t.Error("UnsafeContext should return the raw secret value")
+		}
+	})
+
+	t.Run("RedactKeys", func(t *testing.T) {
+		restore := RedactKeys("password")
+		t.Cleanup(restore)
+		err := New("login failed").With("password", "hunter2")
+		if strings.Contains(err.Error(), "hunter2") {
+			t.Error("Globally redacted keys should not appear in the formatted error")
+		}
+		if err.Context()["password"] != Redacted {
+			t.Error("Context should redact globally redacted keys")
+		}
+
+		RedactKeys("password")()
+		if err.Context()["password"] != Redacted {
+			t.Error("Restoring should keep keys registered by an earlier call")
+		}
+		restore()
+		if err.Context()["password"] != "hunter2" {
+			t.Error("Restoring should unmark the keys the call added")
+		}
+	})
+}
+
+func TestGuard(t *testing.T) {
+	t.Run("True", func(t *testing.T) {
+		if err := Guard(true, "id required"); err != nil {
+			t.Error("Guard should return nil when the condition holds")
+		}
+		if err := Guardf(true, "id %q invalid", "x"); err != nil {
+			t.Error("Guardf should return nil when the condition holds")
+		}
+	})
+
+	t.Run("False", func(t *testing.T) {
+		err := Guard(false, "id required")
+		if err == nil || !strings.Contains(err.Error(), "id required") {
+			t.Error("Guard should return an error with the message when the condition fails")
+		}
+		var zerr *Error
+		if !errors.As(err, &zerr) || !strings.HasPrefix(zerr.stackTrace, "github.com/crazywolf132/safezone.TestGuard") {
+			t.Error("Guard stack trace should originate at the Guard call")
+		}
+	})
+
+	t.Run("Guardf", func(t *testing.T) {
+		err := Guardf(false, "id %q invalid", "x")
+		if err == nil || !strings.Contains(err.Error(), `id "x" invalid`) {
+			t.Error("Guardf should return an error with the formatted message")
+		}
+	})
+}
+
+func TestIs(t *testing.T) {
+	t.Run("SentinelThroughWraps", func(t *testing.T) {
+		err := Wrap(Wrap(ErrTest, "inner"), "outer")
+		if !errors.Is(err, ErrTest) {
+			t.Error("errors.Is should find a sentinel through safezone Wrap layers")
+		}
+	})
+
+	t.Run("ErrorSentinel", func(t *testing.T) {
+		sentinel := New("not found")
+		err := Wrap(Wrap(sentinel, "inner"), "outer")
+		if !errors.Is(err, sentinel) {
+			t.Error("errors.Is should find an *Error sentinel through Wrap layers")
+		}
+		if errors.Is(err, New("not found")) {
+			t.Error("errors.Is should not match a distinct *Error with the same message")
+		}
+	})
+
+	t.Run("Code", func(t *testing.T) {
+		sentinel := New("not found").WithCode("not_found")
+		err := Wrap(New("user 42 not found").WithCode("not_found"), "lookup failed")
+		if !errors.Is(err, sentinel) {
+			t.Error("errors.Is should match an *Error sentinel by code")
+		}
+		if errors.Is(err, New("forbidden").WithCode("forbidden")) {
+			t.Error("errors.Is should not match a different code")
+		}
+	})
+
+	t.Run("AsError", func(t *testing.T) {
+		inner := New("disk full").WithCode("disk_full")
+		err := fmt.Errorf("request failed: %w", fmt.Errorf("save failed: %w", inner))
+		found, ok := AsError(err)
+		if !ok || found != inner {
+			t.Error("AsError should find an *Error nested beneath standard wraps")
+		}
+		if _, ok := AsError(errors.New("plain")); ok {
+			t.Error("AsError should report false when there is no *Error in the chain")
+		}
+	})
+
+	t.Run("SameCode", func(t *testing.T) {
+		a := Wrap(New("a").WithCode("conflict"), "wrapped")
+		b := New("b").WithCode("conflict")
+		if !SameCode(a, b) {
+			t.Error("SameCode should match errors with the same code")
+		}
+		if SameCode(a, New("c").WithCode("other")) {
+			t.Error("SameCode should not match errors with different codes")
+		}
+		if SameCode(errors.New("x"), errors.New("y")) {
+			t.Error("SameCode should not match errors without codes")
+		}
+	})
+}
+
+func TestClone(t *testing.T) {
+	original := New("shared").With("key", "value").WithCode("shared").WithSeverity(SeverityWarn)
+	clone := original.Clone().With("extra", "data")
+
+	if _, ok := original.Context()["extra"]; ok {
+		t.Error("Adding context to a clone should not change the original")
+	}
+	if clone.Context()["key"] != "value" || clone.Context()["extra"] != "data" {
+		t.Error("Clone should keep the original context and accept new keys")
+	}
+	if clone.Code() != "shared" || clone.Severity() != SeverityWarn {
+		t.Error("Clone should copy the code and severity")
+	}
+	if !errors.Is(clone, original) {
+		t.Error("Clone should still match the original with errors.Is")
+	}
+}
+
+func TestConcurrentWith(t *testing.T) {
+	shared := New("shared sentinel")
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			shared.With(fmt.Sprintf("key%d", i), i)
+			_ = shared.Error()
+		}(i)
+	}
+	wg.Wait()
+	if len(shared.Context()) != 50 {
+		t.Errorf("Expected 50 context keys, got %d", len(shared.Context()))
+	}
+}
+
+func TestWithCause(t *testing.T) {
+	err := New("checkout failed").With("order", 42).WithCause(ErrTest)
+	if !errors.Is(err, ErrTest) {
+		t.Error("errors.Is should find the attached cause")
+	}
+	if err.Unwrap() != ErrTest {
+		t.Error("Unwrap should return the attached cause")
+	}
+	if !strings.Contains(err.Error(), "checkout failed") || err.Context()["order"] != 42 {
+		t.Error("WithCause should keep the message and context")
+	}
+
+	wrapped := Wrap(fmt.Errorf("db: %w", &net.OpError{Op: "dial", Err: ErrTest}), "checkout failed").WithCause(context.Canceled)
+	if !errors.Is(wrapped, context.Canceled) {
+		t.Error("errors.Is should find the attached cause")
+	}
+	if !errors.Is(wrapped, ErrTest) {
+		t.Error("errors.Is should still find the originally wrapped error")
+	}
+	var opErr *net.OpError
+	if !errors.As(wrapped, &opErr) || opErr.Op != "dial" {
+		t.Error("errors.As should still find the originally wrapped error")
+	}
+
+	inner := New("payment declined").WithCode("DECLINED").WithSeverity(SeverityFatal).WithStatus(402).WithHint("use another card").WithTags("billing")
+	outer := Wrap(inner, "checkout failed").WithCause(context.Canceled)
+	if !errors.Is(outer, New("other").WithCode("DECLINED")) {
+		t.Error("errors.Is should match a code in the originally wrapped chain")
+	}
+	if outer.Code() != "DECLINED" || outer.Severity() != SeverityFatal || outer.Status() != 402 {
+		t.Error("Code, Severity and Status should see the originally wrapped chain")
+	}
+	if outer.Hint() != "use another card" || !outer.HasTag("billing") {
+		t.Error("Hint and HasTag should see the originally wrapped chain")
+	}
+}
+
+func TestPoll(t *testing.T) {
+	t.Run("EventualDone", func(t *testing.T) {
+		calls := 0
+		err := Poll(context.Background(), 10*time.Millisecond, func() (bool, error) {
+			calls++
+			return calls == 3, nil
+		})
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", calls)
+		}
+	})
+
+	t.Run("Immediate", func(t *testing.T) {
+		start := time.Now()
+		Poll(context.Background(), time.Hour, func() (bool, error) { return true, nil })
+		if time.Since(start) > time.Second {
+			t.Error("Poll should call f immediately")
+		}
+	})
+
+	t.Run("ErrorAbort", func(t *testing.T) {
+		calls := 0
+		err := Poll(context.Background(), 10*time.Millisecond, func() (bool, error) {
+			calls++
+			return false, ErrTest
+		})
+		if !errors.Is(err, ErrTest) || calls != 1 {
+			t.Error("Poll should stop at the first error")
+		}
+	})
+
+	t.Run("ContextCancellation", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := Poll(ctx, 10*time.Millisecond, func() (bool, error) { return false, nil })
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Error("Poll should respect context cancellation")
+		}
+	})
+}
+
+func TestContextError(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		err := New("request failed").With("request_id", "abc")
+		ctx := ContextWithError(context.Background(), err)
+		if ErrorFromContext(ctx) != err {
+			t.Error("ErrorFromContext should return the stored error")
+		}
+	})
+
+	t.Run("Absent", func(t *testing.T) {
+		if ErrorFromContext(context.Background()) != nil {
+			t.Error("ErrorFromContext should return nil when no error is stored")
+		}
+	})
+}
+
+func TestReduce(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		sum := Reduce([]int{1, 2, 3, 4}, 0, func(acc, i int) (int, error) { return acc + i, nil })
+		if sum.Unwrap() != 10 {
+			t.Error("Reduce should fold every item into the accumulator")
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		var visited []int
+		result := Reduce([]int{1, 2, 3, 4}, 0, func(acc, i int) (int, error) {
+			visited = append(visited, i)
+			if i == 3 {
+				return 0, ErrTest
+			}
+			return acc + i, nil
+		})
+		if !errors.Is(result.Check(), ErrTest) {
+			t.Error("Reduce should return the folding function's error")
+		}
+		if len(visited) != 3 {
+			t.Errorf("Reduce should stop at the first error, visited %v", visited)
+		}
+		var zerr *Error
+		if !errors.As(result.Check(), &zerr) || zerr.Context()["index"] != 2 {
+			t.Error("Reduce error should record the failing index")
+		}
+	})
+}
+
+func TestTime(t *testing.T) {
+	pinned := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	t.Cleanup(SetClock(func() time.Time { return pinned }))
+
+	err := New("timed")
+	if !err.Time().Equal(pinned) {
+		t.Errorf("Expected time %v, got %v", pinned, err.Time())
+	}
+	if !Wrap(err, "wrapped").Time().Equal(pinned) {
+		t.Error("Wrap should timestamp the error with the clock")
+	}
+	data, _ := json.Marshal(err)
+	if !strings.Contains(string(data), "2024-01-02T03:04:05Z") {
+		t.Error("MarshalJSON should include the creation time")
+	}
+}
+
+func TestAll(t *testing.T) {
+	t.Run("Ordering", func(t *testing.T) {
+		results, err := All(context.Background(),
+			func(context.Context) (int, error) { time.Sleep(20 * time.Millisecond); return 1, nil },
+			func(context.Context) (int, error) { return 2, nil },
+			func(context.Context) (int, error) { time.Sleep(10 * time.Millisecond); return 3, nil },
+		)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		for i, r := range results {
+			if r.Unwrap() != i+1 {
+				t.Errorf("Expected result %d at index %d, got %d", i+1, i, r.Unwrap())
+			}
+		}
+	})
+
+	t.Run("PartialFailure", func(t *testing.T) {
+		results, err := All(context.Background(),
+			func(context.Context) (string, error) { return "ok", nil },
+			func(context.Context) (string, error) { return "", ErrTest },
+		)
+		if !errors.Is(err, ErrTest) {
+			t.Error("All should return the joined failures")
+		}
+		if results[0].Unwrap() != "ok" {
+			t.Error("All should keep the succeeding values")
+		}
+		if !results[1].ContainsErr(ErrTest) {
+			t.Error("All should keep the per-task errors")
+		}
+	})
+}
+
+func TestTags(t *testing.T) {
+	t.Run("AcrossWrap", func(t *testing.T) {
+		inner := New("connection reset").WithTags("transient", "retryable")
+		outer := Wrap(inner, "fetch failed").WithTags("user-facing", "retryable")
+		for _, tag := range []string{"transient", "retryable", "user-facing"} {
+			if !outer.HasTag(tag) {
+				t.Errorf("Expected tag %q to be present", tag)
+			}
+		}
+		tags := outer.Tags()
+		if strings.Join(tags, ",") != "retryable,transient,user-facing" {
+			t.Errorf("Expected the union of tags, got %v", tags)
+		}
+	})
+
+	t.Run("InnerOnly", func(t *testing.T) {
+		inner := New("timeout").WithTags("transient")
+		outer := Wrap(Wrap(inner, "middle"), "outer")
+		if !outer.HasTag("transient") {
+			t.Error("HasTag should find a tag only present on the inner error")
+		}
+		if outer.HasTag("fatal") {
+			t.Error("HasTag should not find a missing tag")
+		}
+	})
+}
+
+func TestChain(t *testing.T) {
+	level2 := fmt.Errorf("level 2: %w", ErrTest)
+	level3 := New("level 3").WithCause(level2)
+	level4 := New("level 4").WithCause(level3)
+
+	chain := level4.Chain()
+	expected := []error{level4, level3, level2, ErrTest}
+	if len(chain) != len(expected) {
+		t.Fatalf("Expected %d errors in the chain, got %d", len(expected), len(chain))
+	}
+	for i := range expected {
+		if chain[i] != expected[i] {
+			t.Errorf("Unexpected error at position %d: %v", i, chain[i])
+		}
+	}
+	if level4.Root() != ErrTest {
+		t.Error("Root should return the innermost error")
+	}
+
+	var visited int
+	Walk(level4, func(err error) bool {
+		visited++
+		return err != level3
+	})
+	if visited != 2 {
+		t.Errorf("Walk should stop when fn returns false, visited %d", visited)
+	}
+}
+
+type testContextKey string
+
+func TestWrapContext(t *testing.T) {
+	t.Cleanup(RegisterContextKey("request_id", testContextKey("request_id")))
+	t.Cleanup(RegisterContextKey("user_id", testContextKey("user_id")))
+	RegisterContextKey("user_id", testContextKey("user_id"))()
+
+	ctx := context.WithValue(context.Background(), testContextKey("request_id"), "req-1")
+	ctx = context.WithValue(ctx, testContextKey("user_id"), 42)
+
+	err := WrapContext(ctx, ErrTest, "handler failed")
+	if !errors.Is(err, ErrTest) {
+		t.Error("WrapContext should wrap the original error")
+	}
+	if err.Context()["request_id"] != "req-1" || err.Context()["user_id"] != 42 {
+		t.Errorf("WrapContext should copy registered keys, got %v", err.Context())
+	}
+	if WrapContext(ctx, nil, "handler failed") != nil {
+		t.Error("WrapContext should return nil for a nil error")
+	}
+
+	contextFieldsMux.RLock()
+	registered := len(contextFields)
+	contextFieldsMux.RUnlock()
+	if registered != 2 {
+		t.Errorf("A duplicate registration should be ignored and its restore a no-op, got %d keys", registered)
+	}
+}
+
+func TestCollectMap(t *testing.T) {
+	parse := func(s string) Result[int] {
+		if s == "" {
+			return Err[int](ErrTest)
+		}
+		return Ok(len(s))
+	}
+
+	t.Run("AllOk", func(t *testing.T) {
+		values := CollectMap([]string{"a", "bb", "ccc"}, parse).Unwrap()
+		if len(values) != 3 || values[0] != 1 || values[1] != 2 || values[2] != 3 {
+			t.Errorf("Expected [1 2 3], got %v", values)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		values := CollectMap(nil, parse).Unwrap()
+		if values == nil || len(values) != 0 {
+			t.Error("CollectMap should return Ok of an empty slice for empty input")
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		result := CollectMap([]string{"a", "", "ccc"}, parse)
+		if !result.ContainsErr(ErrTest) {
+			t.Error("CollectMap should return the element's error")
+		}
+		var zerr *Error
+		if !errors.As(result.Check(), &zerr) || zerr.Context()["index"] != 1 {
+			t.Error("CollectMap error should record the failing index")
+		}
+	})
+}
+
+func TestDeadline(t *testing.T) {
+	t.Run("PastDeadline", func(t *testing.T) {
+		result := Deadline(time.Now().Add(-time.Second), func(context.Context) (int, error) {
+			t.Error("Deadline should not run f when the deadline has passed")
+			return 0, nil
+		})
+		if !result.ContainsErr(context.DeadlineExceeded) {
+			t.Error("Deadline should return a deadline error for a past deadline")
+		}
+	})
+
+	t.Run("Timely", func(t *testing.T) {
+		result := Deadline(time.Now().Add(time.Second), func(context.Context) (int, error) {
+			return 42, nil
+		})
+		if result.Unwrap() != 42 {
+			t.Error("Deadline should return the value when f completes in time")
+		}
+	})
+
+	t.Run("Exceeded", func(t *testing.T) {
+		start := time.Now()
+		result := Deadline(time.Now().Add(50*time.Millisecond), func(ctx context.Context) (int, error) {
+			time.Sleep(time.Second)
+			return 42, nil
+		})
+		if !result.ContainsErr(context.DeadlineExceeded) {
+			t.Error("Deadline should return a deadline error when f overruns")
+		}
+		if time.Since(start) > 500*time.Millisecond {
+			t.Error("Deadline should return promptly when the deadline passes")
+		}
+	})
+}
+
+func TestWithFaultInjection(t *testing.T) {
+	t.Run("Seeded", func(t *testing.T) {
+		const n, rate = 1000, 0.3
+		expected := 0
+		reference := rand.New(rand.NewSource(42))
+		for i := 0; i < n; i++ {
+			if reference.Float64() < rate {
+				expected++
+			}
+		}
+
+		f := WithFaultInjection(func() (int, error) { return 1, nil }, rate, rand.New(rand.NewSource(42)))
+		failures := 0
+		for i := 0; i < n; i++ {
+			if _, err := f(); errors.Is(err, ErrInjectedFault) {
+				failures++
+			}
+		}
+		if failures != expected {
+			t.Errorf("Expected %d injected failures, got %d", expected, failures)
+		}
+		if failures == 0 || failures == n {
+			t.Error("Expected a mix of injected failures and successes")
+		}
+	})
+
+	t.Run("ZeroRate", func(t *testing.T) {
+		calls := 0
+		f := WithFaultInjection(func() (int, error) { calls++; return 42, nil }, 0, nil)
+		for i := 0; i < 100; i++ {
+			if v, err := f(); err != nil || v != 42 {
+				t.Fatal("A zero failure rate should pass every call through")
+			}
+		}
+		if calls != 100 {
+			t.Errorf("Expected 100 calls, got %d", calls)
+		}
+	})
+}
+
+func TestLazy(t *testing.T) {
+	t.Run("Once", func(t *testing.T) {
+		var calls int32
+		lazy := NewLazy(func() (int, error) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(10 * time.Millisecond)
+			return 42, nil
+		})
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if lazy.Get().Unwrap() != 42 {
+					t.Error("Get should return the computed value")
+				}
+			}()
+		}
+		wg.Wait()
+		if calls != 1 {
+			t.Errorf("Expected f to run once, ran %d times", calls)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		lazy := NewLazy(func() (int, error) { return 0, ErrTest })
+		if !lazy.Get().ContainsErr(ErrTest) || !lazy.Get().ContainsErr(ErrTest) {
+			t.Error("Get should cache the error Result")
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		calls := 0
+		lazy := NewLazy(func() (int, error) {
+			calls++
+			panic("boom")
+		})
+		for i := 0; i < 2; i++ {
+			r := lazy.Get()
+			if r.Check() == nil || !strings.Contains(r.Check().Error(), "boom") {
+				t.Errorf("Get should return the panic as an Err on every call, got %v", r)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("Expected f to run once, ran %d times", calls)
+		}
+	})
+}
+
+func TestFanIn(t *testing.T) {
+	t.Run("Merge", func(t *testing.T) {
+		errA, errB := errors.New("a failed"), errors.New("b failed")
+		a, b := make(chan error), make(chan error)
+		go func() {
+			a <- nil
+			a <- errA
+			close(a)
+		}()
+		go func() {
+			b <- errB
+			close(b)
+		}()
+		err := FanIn(context.Background(), a, b)
+		if !errors.Is(err, errA) || !errors.Is(err, errB) {
+			t.Error("FanIn should join errors from every channel")
+		}
+	})
+
+	t.Run("NoErrors", func(t *testing.T) {
+		a := make(chan error)
+		close(a)
+		if err := FanIn(context.Background(), a); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("ContextCancellation", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		never := make(chan error)
+		if err := FanIn(ctx, never); !errors.Is(err, context.DeadlineExceeded) {
+			t.Error("FanIn should return when ctx is done")
+		}
+	})
+}
+
+func TestHint(t *testing.T) {
+	t.Run("Set", func(t *testing.T) {
+		err := New("401 from upstream").WithHint("check your API key")
+		if err.Hint() != "check your API key" {
+			t.Errorf("Unexpected hint: %q", err.Hint())
+		}
+		if strings.Contains(err.Error(), "check your API key") {
+			t.Error("Hints should not appear in Error()")
+		}
+		data, _ := json.Marshal(err)
+		if !strings.Contains(string(data), `"hint":"check your API key"`) {
+			t.Error("MarshalJSON should include the hint")
+		}
+	})
+
+	t.Run("Inherited", func(t *testing.T) {
+		inner := New("401 from upstream").WithHint("check your API key")
+		if Wrap(inner, "sync failed").Hint() != "check your API key" {
+			t.Error("Hint should be inherited through Wrap")
+		}
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		if New("plain").Hint() != "" {
+			t.Error("Hint should default to an empty string")
+		}
+	})
+}
+
+func TestPartition(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+
+	t.Run("AllOk", func(t *testing.T) {
+		oks, errs := Partition([]Result[int]{Ok(1), Ok(2)})
+		if len(oks) != 2 || oks[0] != 1 || oks[1] != 2 || len(errs) != 0 {
+			t.Errorf("Unexpected partition: %v %v", oks, errs)
+		}
+	})
+
+	t.Run("AllErr", func(t *testing.T) {
+		oks, errs := Partition([]Result[int]{Err[int](errA), Err[int](errB)})
+		if len(oks) != 0 || len(errs) != 2 || errs[0] != errA || errs[1] != errB {
+			t.Errorf("Unexpected partition: %v %v", oks, errs)
+		}
+	})
+
+	t.Run("Mixed", func(t *testing.T) {
+		oks, errs := Partition([]Result[int]{Ok(1), Err[int](errA), Ok(3), Err[int](errB)})
+		if len(oks) != 2 || oks[0] != 1 || oks[1] != 3 {
+			t.Errorf("Unexpected values: %v", oks)
+		}
+		if len(errs) != 2 || errs[0] != errA || errs[1] != errB {
+			t.Errorf("Unexpected errors: %v", errs)
+		}
+	})
+}
+
+func TestTrace(t *testing.T) {
+	t.Run("Set", func(t *testing.T) {
+		err := New("boom").WithTrace("trace-1", "span-1")
+		if err.TraceID() != "trace-1" || err.SpanID() != "span-1" {
+			t.Errorf("Unexpected trace IDs: %q %q", err.TraceID(), err.SpanID())
+		}
+		if New("plain").TraceID() != "" || New("plain").SpanID() != "" {
+			t.Error("Trace IDs should default to empty strings")
+		}
+	})
+
+	t.Run("Inherited", func(t *testing.T) {
+		inner := New("boom").WithTrace("trace-1", "span-1")
+		outer := Wrap(inner, "handler failed")
+		if outer.TraceID() != "trace-1" || outer.SpanID() != "span-1" {
+			t.Error("Trace IDs should be inherited through Wrap")
+		}
+		outer.WithTrace("trace-1", "span-2")
+		if outer.SpanID() != "span-2" || inner.SpanID() != "span-1" {
+			t.Error("An outer trace should override the inner one without modifying it")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, _ := json.Marshal(New("boom").WithTrace("trace-1", "span-1"))
+		if !strings.Contains(string(data), `"trace_id":"trace-1"`) || !strings.Contains(string(data), `"span_id":"span-1"`) {
+			t.Errorf("MarshalJSON should include the trace IDs, got %s", data)
+		}
+		data, _ = json.Marshal(New("plain"))
+		if strings.Contains(string(data), "trace_id") {
+			t.Error("MarshalJSON should omit empty trace IDs")
+		}
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		clone := New("boom").WithTrace("trace-1", "span-1").Clone()
+		if clone.TraceID() != "trace-1" || clone.SpanID() != "span-1" {
+			t.Error("Clone should copy the trace IDs")
+		}
+	})
+
+	t.Run("LogValue", func(t *testing.T) {
+		var buf strings.Builder
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		err := New("boom").WithTrace("trace-1", "span-1").WithSecret("password", "hunter2")
+		logger.Error("request failed", "err", err)
+		out := buf.String()
+		if !strings.Contains(out, `"trace_id":"trace-1"`) || !strings.Contains(out, `"span_id":"span-1"`) {
+			t.Errorf("LogValue should include the trace IDs, got %s", out)
+		}
+		if strings.Contains(out, "hunter2") {
+			t.Error("LogValue should redact sensitive context")
+		}
+	})
+}
+
+func TestHasContext(t *testing.T) {
+	inner := New("record not found").With("id", 42).With("tables", []string{"users"})
+	err := fmt.Errorf("handler: %w", Wrap(Wrap(inner, "load failed"), "request failed"))
+
+	if !HasContext(err, "id", 42) {
+		t.Error("HasContext should find context set several wraps deep")
+	}
+	if !HasContext(err, "tables", []string{"users"}) {
+		t.Error("HasContext should compare values structurally")
+	}
+	if HasContext(err, "id", 7) || HasContext(err, "missing", 42) {
+		t.Error("HasContext should not match a different value or a missing key")
+	}
+	if HasContext(errors.New("plain"), "id", 42) {
+		t.Error("HasContext should not match errors without context")
+	}
+}
+
+func TestHasMessage(t *testing.T) {
+	err := Wrap(Wrap(New("record not found"), "load failed"), "request failed")
+	if !HasMessage(err, "not found") {
+		t.Error("HasMessage should find a message several wraps deep")
+	}
+	if HasMessage(err, "Stack Trace") {
+		t.Error("HasMessage should not match the formatted stack trace")
+	}
+	if !HasMessage(fmt.Errorf("outer: %w", err), "outer") {
+		t.Error("HasMessage should check standard wrapped errors")
+	}
+}
+
+func TestGather(t *testing.T) {
+	sum := func(acc, n int) int { return acc + n }
+
+	t.Run("Sum", func(t *testing.T) {
+		var fns []func() (int, error)
+		for i := 1; i <= 100; i++ {
+			i := i
+			fns = append(fns, func() (int, error) { return i, nil })
+		}
+		result := Gather(context.Background(), 0, sum, fns...)
+		if result.Unwrap() != 5050 {
+			t.Errorf("Expected 5050, got %v", result)
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		result := Gather(context.Background(), 0, sum,
+			func() (int, error) { return 1, nil },
+			func() (int, error) { return 0, ErrTest },
+		)
+		if !result.ContainsErr(ErrTest) {
+			t.Error("Gather should return the failures")
+		}
+	})
+
+	t.Run("ContextDone", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		result := Gather(ctx, 0, sum,
+			func() (int, error) { return 1, nil },
+			func() (int, error) { time.Sleep(time.Second); return 2, nil },
+		)
+		if !result.ContainsErr(context.DeadlineExceeded) {
+			t.Error("Gather should return the context error when ctx is done first")
+		}
+	})
+}
+
+func TestEqualsValue(t *testing.T) {
+	build := func(id int) *Error {
+		return Wrap(New("not found").With("table", "users"), "lookup failed").
+			WithCode("E_NOT_FOUND").
+			With("id", id).
+			With("attempt", 1)
+	}
+
+	t.Run("Equal", func(t *testing.T) {
+		a := build(42)
+		b := Wrap(New("not found").With("table", "users"), "lookup failed").
+			WithCode("E_NOT_FOUND").
+			With("attempt", 1).
+			With("id", 42)
+		if !a.EqualsValue(b) {
+			t.Error("Errors with identical fields should compare equal")
+		}
+	})
+
+	t.Run("Different", func(t *testing.T) {
+		a := build(42)
+		if a.EqualsValue(build(7)) {
+			t.Error("Errors with different context should not compare equal")
+		}
+		if a.EqualsValue(build(42).WithCode("E_OTHER")) {
+			t.Error("Errors with different codes should not compare equal")
+		}
+		if New("a").EqualsValue(New("b")) {
+			t.Error("Errors with different messages should not compare equal")
+		}
+		if a.EqualsValue(nil) {
+			t.Error("An Error should not compare equal to nil")
+		}
+	})
+}
+
+func TestFromChannel(t *testing.T) {
+	ch := make(chan Result[int])
+	go func() {
+		defer close(ch)
+		Ok(1).Send(ch)
+		Err[int](ErrTest).Send(ch)
+		Ok(3).Send(ch)
+	}()
+	rs := FromChannel(ch)
+	if len(rs) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(rs))
+	}
+	if rs[0].Unwrap() != 1 || !rs[1].ContainsErr(ErrTest) || rs[2].Unwrap() != 3 {
+		t.Errorf("FromChannel should preserve send order, got %v", rs)
+	}
+
+	empty := make(chan Result[int])
+	close(empty)
+	if len(FromChannel(empty)) != 0 {
+		t.Error("FromChannel should return no results for a closed empty channel")
+	}
+}
+
+func TestMaxContextSize(t *testing.T) {
+	defer func(old int) { MaxContextSize = old }(MaxContextSize)
+	MaxContextSize = 3
+
+	t.Run("DropsOldest", func(t *testing.T) {
+		err := New("boom")
+		for i := 0; i < 10; i++ {
+			err.With(fmt.Sprintf("key%d", i), i)
+		}
+		ctx := err.UnsafeContext()
+		if len(ctx) != 3 {
+			t.Fatalf("Expected 3 context keys, got %d", len(ctx))
+		}
+		for _, key := range []string{"key7", "key8", "key9"} {
+			if _, ok := ctx[key]; !ok {
+				t.Errorf("Expected the newest key %s to be kept, got %v", key, ctx)
+			}
+		}
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		err := New("boom").With("a", 1).With("b", 2).With("c", 3).With("a", 4).With("d", 5)
+		ctx := err.UnsafeContext()
+		if _, ok := ctx["a"]; ok {
+			t.Error("Overwriting a key should not make it newer")
+		}
+		if ctx["b"] != 2 || ctx["c"] != 3 || ctx["d"] != 5 {
+			t.Errorf("Unexpected context: %v", ctx)
+		}
+	})
+
+	t.Run("Secrets", func(t *testing.T) {
+		err := New("boom").WithSecret("token", "s3cret").With("a", 1).With("b", 2).With("c", 3)
+		err.With("token", "public")
+		if err.Context()["token"] != "public" {
+			t.Error("A dropped secret should not redact a later value for the same key")
+		}
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		MaxContextSize = 0
+		defer func() { MaxContextSize = 3 }()
+		err := New("boom")
+		for i := 0; i < 10; i++ {
+			err.With(fmt.Sprintf("key%d", i), i)
+		}
+		if len(err.UnsafeContext()) != 10 {
+			t.Error("A zero MaxContextSize should keep every key")
+		}
+	})
+}
+
+func TestSetErrorHook(t *testing.T) {
+	defer SetErrorHook(nil)
+
+	t.Run("Fires", func(t *testing.T) {
+		var seen []string
+		SetErrorHook(func(e *Error) { seen = append(seen, e.err.Error()) })
+		inner := New("boom")
+		Wrap(inner, "request failed")
+		SetErrorHook(nil)
+		New("after removal")
+		if len(seen) != 2 || seen[0] != "boom" || !strings.HasPrefix(seen[1], "request failed") {
+			t.Errorf("The hook should fire for New and Wrap only while set, got %v", seen)
+		}
+	})
+
+	t.Run("Recursion", func(t *testing.T) {
+		var calls int
+		SetErrorHook(func(e *Error) {
+			calls++
+			Wrap(e, "hooked")
+		})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			New("boom")
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("A hook that creates errors should not recurse or deadlock")
+		}
+		if calls != 1 {
+			t.Errorf("Expected the hook to fire once, got %d", calls)
+		}
+	})
+
+	t.Run("SeesConstructorContext", func(t *testing.T) {
+		var seen []map[string]interface{}
+		SetErrorHook(func(e *Error) { seen = append(seen, e.UnsafeContext()) })
+		WrapIf(ErrTest, "wrapped", map[string]interface{}{"id": 7})
+		Ok(-1).Ensure(func(i int) bool { return i > 0 }, "must be positive")
+		SetErrorHook(nil)
+		if len(seen) != 2 || seen[0]["id"] != 7 || seen[1]["value"] != -1 {
+			t.Errorf("The hook should see context added by constructors, got %v", seen)
+		}
+	})
+
+	t.Run("OtherGoroutines", func(t *testing.T) {
+		inHook := make(chan struct{})
+		release := make(chan struct{})
+		var calls int32
+		SetErrorHook(func(e *Error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				close(inHook)
+				<-release
+			}
+		})
+		defer SetErrorHook(nil)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			New("first")
+		}()
+		<-inHook
+		New("second")
+		close(release)
+		<-done
+		if atomic.LoadInt32(&calls) != 2 {
+			t.Errorf("The hook should fire for errors on other goroutines while it runs, got %d calls", calls)
+		}
+	})
+}
+
+func TestLocation(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	err := New("boom")
+	if want := fmt.Sprintf("safezone_test.go:%d", line+1); !strings.HasSuffix(err.Location(), want) {
+		t.Errorf("Expected location ending in %s, got %q", want, err.Location())
+	}
+
+	_, _, line, _ = runtime.Caller(0)
+	result := Try(func() (int, error) { return 0, ErrTest })
+	ze := result.Check().(*Error)
+	if want := fmt.Sprintf("safezone_test.go:%d", line+1); !strings.HasSuffix(ze.Location(), want) {
+		t.Errorf("Location should skip frames inside safezone, expected %s, got %q", want, ze.Location())
+	}
+}
+
+func TestValidate(t *testing.T) {
+	errName, errAge := errors.New("name is required"), errors.New("age must be positive")
+
+	t.Run("Failures", func(t *testing.T) {
+		err := Validate(
+			func() error { return Wrap(errName, "invalid user") },
+			func() error { return nil },
+			func() error { return errAge },
+		)
+		if !errors.Is(err, errName) || !errors.Is(err, errAge) {
+			t.Errorf("Validate should report every failure, got %v", err)
+		}
+		if !HasContext(err, "failures", 2) {
+			t.Error("Validate should record the number of failures")
+		}
+	})
+
+	t.Run("Pass", func(t *testing.T) {
+		if err := Validate(func() error { return nil }); err != nil {
+			t.Errorf("Validate should return nil when every check passes, got %v", err)
+		}
+	})
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		original := New("payment declined").
+			WithCode("E_DECLINED").
+			WithHint("try another card").
+			WithTrace("trace-1", "span-1").
+			WithSeverity(SeverityWarn).
+			WithStatus(402).
+			With("order", "A-17").
+			WithSecret("card", "4242")
+		data, err := json.Marshal(original)
+		if err != nil {
+			t.Fatalf("Unexpected marshal error: %v", err)
+		}
+
+		var decoded Error
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unexpected unmarshal error: %v", err)
+		}
+		if decoded.err.Error() != "payment declined" || decoded.Code() != "E_DECLINED" || decoded.Hint() != "try another card" {
+			t.Errorf("Unexpected message, code or hint: %q %q %q", decoded.err, decoded.Code(), decoded.Hint())
+		}
+		if decoded.TraceID() != "trace-1" || decoded.SpanID() != "span-1" {
+			t.Error("UnmarshalJSON should restore the trace IDs")
+		}
+		if decoded.Severity() != SeverityWarn || decoded.Status() != 402 {
+			t.Errorf("Unexpected severity or status: %v %d", decoded.Severity(), decoded.Status())
+		}
+		if decoded.Context()["order"] != "A-17" || decoded.Context()["card"] != Redacted {
+			t.Errorf("Unexpected context: %v", decoded.Context())
+		}
+		if decoded.stackTrace != original.stackTrace || !decoded.Time().Equal(original.Time()) {
+			t.Error("UnmarshalJSON should preserve the stack trace and time")
+		}
+
+		again, _ := json.Marshal(&decoded)
+		if string(again) != string(data) {
+			t.Errorf("Round-tripping should be lossless:\n%s\n%s", data, again)
+		}
+	})
+
+	t.Run("InvalidSeverity", func(t *testing.T) {
+		var decoded Error
+		if err := json.Unmarshal([]byte(`{"message":"boom","severity":"catastrophic"}`), &decoded); err == nil {
+			t.Error("UnmarshalJSON should reject an unknown severity")
+		}
+	})
+}
+
+func TestCombine(t *testing.T) {
+	sum := func(a, b int) int { return a + b }
+
+	t.Run("AllOk", func(t *testing.T) {
+		if got := Combine(sum, Ok(1), Ok(2), Ok(3)).Unwrap(); got != 6 {
+			t.Errorf("Expected 6, got %d", got)
+		}
+		if got := Combine(func(a, b string) string { return a + b }, Ok("a"), Ok("b"), Ok("c")).Unwrap(); got != "abc" {
+			t.Errorf("Combine should reduce left to right, got %q", got)
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		calls := 0
+		result := Combine(func(a, b int) int { calls++; return a + b }, Ok(1), Err[int](ErrTest), Ok(3))
+		if !result.ContainsErr(ErrTest) {
+			t.Error("Combine should return the failing Result")
+		}
+		if calls != 0 {
+			t.Errorf("Combine should stop at the first failure, got %d reducer calls", calls)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if !Combine(sum).ContainsErr(ErrNoResults) {
+			t.Error("Combine should return ErrNoResults for empty input")
+		}
+	})
+}
+
+func TestFilterMap(t *testing.T) {
+	half := func(i int) (string, bool, error) {
+		if i < 0 {
+			return "", false, ErrTest
+		}
+		return strconv.Itoa(i / 2), i%2 == 0, nil
+	}
+
+	t.Run("Keep", func(t *testing.T) {
+		if got := FilterMap(Ok(42), half).Unwrap(); got != "21" {
+			t.Errorf("Expected the mapped value, got %q", got)
+		}
+	})
+
+	t.Run("Drop", func(t *testing.T) {
+		if !FilterMap(Ok(7), half).ContainsErr(ErrFilteredOut) {
+			t.Error("A false keep flag should produce ErrFilteredOut")
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		if !FilterMap(Ok(-1), half).ContainsErr(ErrTest) {
+			t.Error("FilterMap should return f's error")
+		}
+	})
+
+	t.Run("InputError", func(t *testing.T) {
+		result := FilterMap(Err[int](ErrTest), func(int) (string, bool, error) {
+			t.Error("FilterMap should not call f for Err results")
+			return "", true, nil
+		})
+		if !result.ContainsErr(ErrTest) {
+			t.Error("FilterMap should pass the input error through")
+		}
+	})
 }
@@ -0,0 +1,66 @@
+package safezone
+
+import "sync"
+
+// SingleFlight coalesces concurrent calls that share the same key
+type SingleFlight[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*flight[V]
+}
+
+type flight[V any] struct {
+	wg       sync.WaitGroup
+	result   Result[V]
+	panicked bool
+	panicVal interface{}
+}
+
+// Do runs f for key, unless a call for the same key is already in flight,
+// in which case it waits for that call and returns its Result.
+// Once a call completes, the next Do for the key runs f again.
+// If f panics, the panic is re-raised in the caller and in every waiting caller.
+func (s *SingleFlight[K, V]) Do(key K, f func() (V, error)) Result[V] {
+	s.mu.Lock()
+	if s.calls == nil {
+		s.calls = make(map[K]*flight[V])
+	}
+	if c, ok := s.calls[key]; ok {
+		s.mu.Unlock()
+		c.wg.Wait()
+		if c.panicked {
+			panic(c.panicVal)
+		}
+		return c.result
+	}
+	c := &flight[V]{}
+	c.wg.Add(1)
+	s.calls[key] = c
+	s.mu.Unlock()
+
+	returned := false
+	defer func() {
+		if !returned {
+			if r := recover(); r != nil {
+				c.panicked, c.panicVal = true, r
+			} else {
+				c.result = Err[V](New("function called runtime.Goexit"))
+			}
+		}
+		s.mu.Lock()
+		delete(s.calls, key)
+		s.mu.Unlock()
+		c.wg.Done()
+		if c.panicked {
+			panic(c.panicVal)
+		}
+	}()
+
+	value, err := f()
+	returned = true
+	if err != nil {
+		c.result = Err[V](err)
+	} else {
+		c.result = Ok(value)
+	}
+	return c.result
+}
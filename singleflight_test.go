@@ -0,0 +1,83 @@
+package safezone
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSingleFlight(t *testing.T) {
+	t.Run("Coalesces", func(t *testing.T) {
+		var sf SingleFlight[string, int]
+		var calls int32
+		start := make(chan struct{})
+
+		const n = 10
+		var wg sync.WaitGroup
+		results := make([]Result[int], n)
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				results[i] = sf.Do("key", func() (int, error) {
+					atomic.AddInt32(&calls, 1)
+					time.Sleep(100 * time.Millisecond)
+					return 42, nil
+				})
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+
+		if calls != 1 {
+			t.Errorf("Expected f to run once, ran %d times", calls)
+		}
+		for _, r := range results {
+			if r.Unwrap() != 42 {
+				t.Error("All callers should share the in-flight result")
+			}
+		}
+	})
+
+	t.Run("Recomputes", func(t *testing.T) {
+		var sf SingleFlight[string, int]
+		sf.Do("key", func() (int, error) { return 1, nil })
+		if sf.Do("key", func() (int, error) { return 2, nil }).Unwrap() != 2 {
+			t.Error("Later calls should recompute once the in-flight call completes")
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		var sf SingleFlight[string, int]
+		result := sf.Do("key", func() (int, error) { return 0, ErrTest })
+		if !errors.Is(result.Check(), ErrTest) {
+			t.Error("Do should return the function's error")
+		}
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		var sf SingleFlight[string, int]
+		release := make(chan struct{})
+		panics := make(chan interface{}, 2)
+		do := func() {
+			defer func() { panics <- recover() }()
+			sf.Do("key", func() (int, error) {
+				<-release
+				panic("boom")
+			})
+		}
+		go do()
+		time.Sleep(10 * time.Millisecond)
+		go do()
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		for i := 0; i < 2; i++ {
+			if r := <-panics; r != "boom" {
+				t.Errorf("Every caller should see the panic, got %v", r)
+			}
+		}
+	})
+}
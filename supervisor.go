@@ -0,0 +1,67 @@
+package safezone
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultResetAfter is used when Supervisor.ResetAfter is zero
+const defaultResetAfter = time.Minute
+
+// Supervisor keeps long-running tasks alive by restarting them when they fail
+type Supervisor struct {
+	// ResetAfter is how long a run must last before its failure restarts the backoff
+	// sequence from the beginning. If zero, one minute is used.
+	ResetAfter time.Duration
+
+	mu       sync.Mutex
+	restarts map[string]int
+}
+
+// Supervise runs f and restarts it with backoff whenever it returns an error or panics,
+// until f returns nil or ctx is done. It blocks until then and returns nil or ctx.Err().
+// The backoff is reset after a run that lasts at least ResetAfter and when f succeeds.
+func (s *Supervisor) Supervise(ctx context.Context, name string, f func(context.Context) error, backoff Backoff) error {
+	resetAfter := s.ResetAfter
+	if resetAfter == 0 {
+		resetAfter = defaultResetAfter
+	}
+	for {
+		start := time.Now()
+		err := s.run(ctx, f)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == nil {
+			backoff.Reset()
+			return nil
+		}
+		if time.Since(start) >= resetAfter {
+			backoff.Reset()
+		}
+
+		s.mu.Lock()
+		if s.restarts == nil {
+			s.restarts = make(map[string]int)
+		}
+		s.restarts[name]++
+		s.mu.Unlock()
+
+		if err := sleepCtx(ctx, backoff.Next()); err != nil {
+			return err
+		}
+	}
+}
+
+// Restarts returns how many times the named task has been restarted
+func (s *Supervisor) Restarts(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.restarts[name]
+}
+
+func (s *Supervisor) run(ctx context.Context, f func(context.Context) error) (err error) {
+	defer Recover(&err)
+	return f(ctx)
+}
@@ -0,0 +1,86 @@
+package safezone
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSupervisor(t *testing.T) {
+	t.Run("RestartsUntilCancelled", func(t *testing.T) {
+		var s Supervisor
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		runs := 0
+		err := s.Supervise(ctx, "worker", func(ctx context.Context) error {
+			runs++
+			switch runs {
+			case 1:
+				return ErrTest
+			case 2:
+				panic("worker crashed")
+			}
+			cancel()
+			<-ctx.Done()
+			return ctx.Err()
+		}, NewExponential(time.Millisecond, 10*time.Millisecond, 2))
+
+		if !errors.Is(err, context.Canceled) {
+			t.Error("Supervise should return the context error once cancelled")
+		}
+		if runs != 3 {
+			t.Errorf("Expected 3 runs, got %d", runs)
+		}
+		if s.Restarts("worker") != 2 {
+			t.Errorf("Expected 2 restarts, got %d", s.Restarts("worker"))
+		}
+	})
+
+	t.Run("Completes", func(t *testing.T) {
+		var s Supervisor
+		err := s.Supervise(context.Background(), "job", func(context.Context) error {
+			return nil
+		}, NewExponential(time.Millisecond, time.Millisecond, 1))
+		if err != nil || s.Restarts("job") != 0 {
+			t.Error("Supervise should stop without restarting when f succeeds")
+		}
+	})
+	t.Run("ResetsBackoff", func(t *testing.T) {
+		s := Supervisor{ResetAfter: 20 * time.Millisecond}
+		backoff := &recordingBackoff{}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		runs := 0
+		s.Supervise(ctx, "worker", func(ctx context.Context) error {
+			runs++
+			switch runs {
+			case 1:
+				return ErrTest
+			case 2:
+				time.Sleep(30 * time.Millisecond)
+				return ErrTest
+			}
+			cancel()
+			return nil
+		}, backoff)
+		if backoff.resets != 1 || backoff.nexts != 2 {
+			t.Errorf("Only the long-lasting run should reset the backoff, got %d resets and %d delays", backoff.resets, backoff.nexts)
+		}
+
+		backoff = &recordingBackoff{}
+		s.Supervise(context.Background(), "job", func(context.Context) error { return nil }, backoff)
+		if backoff.resets != 1 {
+			t.Error("Supervise should reset the backoff when f succeeds")
+		}
+	})
+}
+
+// recordingBackoff counts calls and never waits
+type recordingBackoff struct {
+	nexts, resets int
+}
+
+func (b *recordingBackoff) Next() time.Duration { b.nexts++; return 0 }
+
+func (b *recordingBackoff) Reset() { b.resets++ }
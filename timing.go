@@ -0,0 +1,44 @@
+package safezone
+
+import (
+	"sync"
+	"time"
+)
+
+// Debounce returns a function that delays calling f until d has elapsed
+// since the last time it was invoked. It is safe for concurrent use.
+func Debounce(d time.Duration, f func()) func() {
+	var (
+		mu    sync.Mutex
+		timer *time.Timer
+	)
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(d, f)
+	}
+}
+
+// Throttle returns a function that calls f at most once per interval d.
+// It is leading-edge: the first call runs f immediately and any calls made
+// before d has elapsed are dropped rather than deferred. It is safe for concurrent use.
+func Throttle(d time.Duration, f func()) func() {
+	var (
+		mu   sync.Mutex
+		last time.Time
+	)
+	return func() {
+		mu.Lock()
+		now := time.Now()
+		if !last.IsZero() && now.Sub(last) < d {
+			mu.Unlock()
+			return
+		}
+		last = now
+		mu.Unlock()
+		f()
+	}
+}
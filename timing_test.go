@@ -0,0 +1,64 @@
+package safezone
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDebounce(t *testing.T) {
+	t.Run("Burst", func(t *testing.T) {
+		var calls int32
+		debounced := Debounce(50*time.Millisecond, func() { atomic.AddInt32(&calls, 1) })
+		for i := 0; i < 10; i++ {
+			debounced()
+			time.Sleep(5 * time.Millisecond)
+		}
+		if atomic.LoadInt32(&calls) != 0 {
+			t.Error("Debounced function should not run while calls keep arriving")
+		}
+		time.Sleep(150 * time.Millisecond)
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Errorf("Expected 1 call after the burst, got %d", n)
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		var calls int32
+		debounced := Debounce(50*time.Millisecond, func() { atomic.AddInt32(&calls, 1) })
+		for i := 0; i < 10; i++ {
+			go debounced()
+		}
+		time.Sleep(150 * time.Millisecond)
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Errorf("Expected 1 call after concurrent triggers, got %d", n)
+		}
+	})
+}
+
+func TestThrottle(t *testing.T) {
+	t.Run("OncePerInterval", func(t *testing.T) {
+		var calls int32
+		throttled := Throttle(100*time.Millisecond, func() { atomic.AddInt32(&calls, 1) })
+		for i := 0; i < 20; i++ {
+			throttled()
+		}
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Errorf("Expected 1 call within the interval, got %d", n)
+		}
+	})
+
+	t.Run("Leading", func(t *testing.T) {
+		var calls int32
+		throttled := Throttle(50*time.Millisecond, func() { atomic.AddInt32(&calls, 1) })
+		throttled()
+		if atomic.LoadInt32(&calls) != 1 {
+			t.Error("The first call should run immediately")
+		}
+		time.Sleep(75 * time.Millisecond)
+		throttled()
+		if n := atomic.LoadInt32(&calls); n != 2 {
+			t.Errorf("Expected a call once the interval passed, got %d calls", n)
+		}
+	})
+}